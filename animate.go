@@ -0,0 +1,136 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Frames is a stream of eased values generated by Animate.
+type Frames struct {
+	// dropped is first to guarantee 64 bits alignment for atomic access.
+	dropped uint64
+
+	// C receives the eased values. It is closed once the animation completed or
+	// the context was canceled.
+	C <-chan uint16
+}
+
+// Dropped returns the number of frames that were skipped because the
+// consumer was not keeping up.
+func (f *Frames) Dropped() uint64 {
+	return atomic.LoadUint64(&f.dropped)
+}
+
+// Animate streams the values of l over the duration d at fps frames per
+// second.
+//
+// The first frame is l.Eval(0) and, unless ctx is canceled first, the last
+// frame is l.Eval(65535).
+//
+// When the consumer is slower than the frame rate, the stale frame is replaced
+// with the most recent one instead of blocking the animation. Dropped()
+// reports how many frames were skipped this way. The last frame is never
+// dropped.
+func Animate(ctx context.Context, l LUT, d time.Duration, fps int) *Frames {
+	if fps <= 0 {
+		// Make invalid `fps` value silently work instead of crashing or inducing
+		// unnecessary error handling.
+		fps = 60
+	}
+	period := time.Second / time.Duration(fps)
+	if period <= 0 {
+		// time.NewTicker panics on a zero period.
+		period = 1
+	}
+	c := make(chan uint16, 1)
+	f := &Frames{C: c}
+	go f.run(ctx, c, l, d, period)
+	return f
+}
+
+func (f *Frames) run(ctx context.Context, c chan uint16, l LUT, d time.Duration, period time.Duration) {
+	defer close(c)
+	if d <= 0 {
+		f.last(ctx, c, l.Eval(65535))
+		return
+	}
+	start := timeNow()
+	t := newTicker(period)
+	defer t.Stop()
+	f.send(c, l.Eval(0))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.Chan():
+			elapsed := now.Sub(start)
+			if elapsed >= d {
+				f.last(ctx, c, l.Eval(65535))
+				return
+			}
+			f.send(c, l.Eval(progress(elapsed, d)))
+		}
+	}
+}
+
+// send sends v without blocking, replacing the pending frame if the consumer
+// didn't pick it up yet.
+func (f *Frames) send(c chan uint16, v uint16) {
+	select {
+	case <-c:
+		atomic.AddUint64(&f.dropped, 1)
+	default:
+	}
+	c <- v
+}
+
+// last sends v, waiting for the consumer to pick it up.
+func (f *Frames) last(ctx context.Context, c chan uint16, v uint16) {
+	select {
+	case <-c:
+		atomic.AddUint64(&f.dropped, 1)
+	default:
+	}
+	select {
+	case c <- v:
+	case <-ctx.Done():
+	}
+}
+
+// progress returns the position of elapsed in d in the uint16 domain.
+//
+// A zero or negative d is complete as soon as it starts.
+func progress(elapsed, d time.Duration) uint16 {
+	if elapsed >= d {
+		return 65535
+	}
+	if elapsed <= 0 {
+		return 0
+	}
+	return uint16(float64(elapsed) * 65535. / float64(d))
+}
+
+// ticker abstracts time.Ticker so it can be replaced in tests.
+type ticker interface {
+	Chan() <-chan time.Time
+	Stop()
+}
+
+type timeTicker struct {
+	*time.Ticker
+}
+
+func (t timeTicker) Chan() <-chan time.Time {
+	return t.C
+}
+
+// Overridden in unit tests.
+var (
+	timeNow   = time.Now
+	newTicker = func(d time.Duration) ticker { return timeTicker{time.NewTicker(d)} }
+)
@@ -0,0 +1,135 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAnimate(t *testing.T) {
+	start, tick, stopped, restore := fakeClock()
+	defer restore()
+	l := Make(0.42, 0, 0.58, 1, 0)
+	f := Animate(context.Background(), l, time.Second, 4)
+	if v := <-f.C; v != 0 {
+		t.Fatalf("first frame %d", v)
+	}
+	for i, x := range []uint16{16383, 32767, 49151} {
+		tick <- start.Add(time.Duration(i+1) * 250 * time.Millisecond)
+		if v := <-f.C; v != l.Eval(x) {
+			t.Fatalf("frame %d: expected %d got %d", i+1, l.Eval(x), v)
+		}
+	}
+	tick <- start.Add(time.Second)
+	if v := <-f.C; v != 65535 {
+		t.Fatalf("last frame %d", v)
+	}
+	if _, ok := <-f.C; ok {
+		t.Fatal("expected channel to be closed")
+	}
+	if !*stopped {
+		t.Fatal("ticker wasn't stopped")
+	}
+	if d := f.Dropped(); d != 0 {
+		t.Fatalf("dropped %d", d)
+	}
+}
+
+func TestAnimate_Slow(t *testing.T) {
+	start, tick, _, restore := fakeClock()
+	defer restore()
+	f := Animate(context.Background(), Make(0.42, 0, 0.58, 1, 0), time.Second, 10)
+	// Do not read the frames until the animation is done.
+	for i := 1; i <= 10; i++ {
+		tick <- start.Add(time.Duration(i) * 100 * time.Millisecond)
+	}
+	// The consumer may race with the last frame and still get the stale one.
+	var got []uint16
+	for v := range f.C {
+		got = append(got, v)
+	}
+	if len(got) == 0 || got[len(got)-1] != 65535 {
+		t.Fatalf("last frame missing: %v", got)
+	}
+	if d := f.Dropped(); d+uint64(len(got)) != 11 || d < 9 {
+		t.Fatalf("dropped %d, received %v", d, got)
+	}
+}
+
+func TestAnimate_Cancel(t *testing.T) {
+	start, tick, stopped, restore := fakeClock()
+	defer restore()
+	ctx, cancel := context.WithCancel(context.Background())
+	f := Animate(ctx, Make(0.42, 0, 0.58, 1, 0), time.Second, 10)
+	<-f.C
+	tick <- start.Add(100 * time.Millisecond)
+	<-f.C
+	cancel()
+	if _, ok := <-f.C; ok {
+		t.Fatal("expected channel to be closed")
+	}
+	if !*stopped {
+		t.Fatal("ticker wasn't stopped")
+	}
+}
+
+func TestAnimate_ZeroDuration(t *testing.T) {
+	_, _, _, restore := fakeClock()
+	defer restore()
+	f := Animate(context.Background(), Make(0.42, 0, 0.58, 1, 0), 0, 0)
+	if v := <-f.C; v != 65535 {
+		t.Fatalf("last frame %d", v)
+	}
+	if _, ok := <-f.C; ok {
+		t.Fatal("expected channel to be closed")
+	}
+}
+
+func TestAnimate_HighFPS(t *testing.T) {
+	// The frame period is less than a nanosecond.
+	f := Animate(context.Background(), Make(0.42, 0, 0.58, 1, 0), time.Millisecond, 2000000000)
+	var last uint16
+	for v := range f.C {
+		last = v
+	}
+	if last != 65535 {
+		t.Fatalf("last frame %d", last)
+	}
+}
+
+type fakeTicker struct {
+	c       chan time.Time
+	stopped *bool
+}
+
+func (f *fakeTicker) Chan() <-chan time.Time {
+	return f.c
+}
+
+func (f *fakeTicker) Stop() {
+	*f.stopped = true
+}
+
+// fakeClock replaces the clock and the ticker until the returned function is
+// called.
+//
+// The returned channel is unbuffered so that each tick is processed before the
+// next one can be sent.
+func fakeClock() (time.Time, chan<- time.Time, *bool, func()) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := make(chan time.Time)
+	stopped := new(bool)
+	oldNow := timeNow
+	oldTicker := newTicker
+	timeNow = func() time.Time { return start }
+	newTicker = func(d time.Duration) ticker { return &fakeTicker{c, stopped} }
+	restore := func() {
+		timeNow = oldNow
+		newTicker = oldTicker
+	}
+	return start, c, stopped, restore
+}
@@ -0,0 +1,162 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"unsafe"
+)
+
+// Binary format of a Bank, all values are little endian:
+//
+//   - magic "FBZB"
+//   - version, 1 byte
+//   - reserved, 1 byte, must be 0
+//   - number of tables N, uint16
+//   - N index entries of 8 bytes each, made of the offset of the table from
+//     the start of the bank in bytes as uint32, the number of values in the
+//     table including the trailing 65535 as uint16 and 2 reserved bytes
+//     that must be 0
+//   - the tables, uint16 values
+//   - CRC32 (IEEE) of all the preceding bytes, uint32
+//
+// Since everything before the tables is a multiple of 2 bytes, all the
+// values are 2 bytes aligned relative to the start of the bank.
+const (
+	bankMagic      = "FBZB"
+	bankVersion    = 1
+	bankHeaderSize = 4 + 1 + 1 + 2
+	bankEntrySize  = 4 + 2 + 2
+)
+
+// Bank is a read-only set of LUTs stored contiguously in a single buffer, as
+// generated by MarshalBank.
+//
+// It is meant to be used with buffers stored in flash or memory mapped. The
+// buffer must not be modified while the Bank is in use.
+type Bank struct {
+	b      []byte
+	values []uint16
+	n      int
+}
+
+// NewBank returns a Bank reading from b after validating it.
+//
+// When the host is little endian and b is 2 bytes aligned, which is always
+// the case for a buffer allocated by Go, the LUTs returned by Get alias b
+// and no memory is allocated. Otherwise the values are copied once.
+func NewBank(b []byte) (*Bank, error) {
+	if len(b) < bankHeaderSize+crcSize {
+		return nil, fmt.Errorf("truncated data: got %d bytes, expected at least %d", len(b), bankHeaderSize+crcSize)
+	}
+	if string(b[:4]) != bankMagic {
+		return nil, fmt.Errorf("invalid magic %q, expected %q", b[:4], bankMagic)
+	}
+	if b[4] != bankVersion {
+		return nil, fmt.Errorf("unsupported version %d, expected %d", b[4], bankVersion)
+	}
+	if b[5] != 0 {
+		return nil, fmt.Errorf("invalid reserved byte 0x%02x", b[5])
+	}
+	n := int(binary.LittleEndian.Uint16(b[6:]))
+	start := bankHeaderSize + n*bankEntrySize
+	if len(b) < start+crcSize {
+		return nil, fmt.Errorf("truncated data: got %d bytes, expected at least %d", len(b), start+crcSize)
+	}
+	end := len(b) - crcSize
+	if got, want := crc32.ChecksumIEEE(b[:end]), binary.LittleEndian.Uint32(b[end:]); got != want {
+		return nil, fmt.Errorf("CRC mismatch: got 0x%08x, expected 0x%08x", got, want)
+	}
+	for i := 0; i < n; i++ {
+		e := b[bankHeaderSize+i*bankEntrySize:]
+		off := int(binary.LittleEndian.Uint32(e))
+		size := int(binary.LittleEndian.Uint16(e[4:]))
+		if r := binary.LittleEndian.Uint16(e[6:]); r != 0 {
+			return nil, fmt.Errorf("table %d: invalid reserved value 0x%04x", i, r)
+		}
+		if off < start || off%2 != 0 || off+2*size > end {
+			return nil, fmt.Errorf("table %d: invalid offset %d", i, off)
+		}
+		if size < 3 {
+			return nil, fmt.Errorf("table %d: invalid size %d", i, size)
+		}
+		if last := binary.LittleEndian.Uint16(b[off+2*size-2:]); last != 65535 {
+			return nil, fmt.Errorf("table %d: last value is %d, expected 65535", i, last)
+		}
+	}
+	bk := &Bank{b: b, n: n}
+	count := end / 2
+	if isLittleEndian && uintptr(unsafe.Pointer(&b[0]))%2 == 0 {
+		bk.values = (*[math.MaxInt32 / 2]uint16)(unsafe.Pointer(&b[0]))[:count:count]
+	} else {
+		bk.values = make([]uint16, count)
+		for i := range bk.values {
+			bk.values[i] = binary.LittleEndian.Uint16(b[2*i:])
+		}
+	}
+	return bk, nil
+}
+
+// Len returns the number of LUTs in the bank.
+func (b *Bank) Len() int {
+	return b.n
+}
+
+// Get returns the LUT at index id, or nil if id is out of range.
+//
+// The LUT must not be modified.
+func (b *Bank) Get(id int) LUT {
+	if id < 0 || id >= b.n {
+		return nil
+	}
+	e := b.b[bankHeaderSize+id*bankEntrySize:]
+	off := int(binary.LittleEndian.Uint32(e)) / 2
+	end := off + int(binary.LittleEndian.Uint16(e[4:]))
+	return LUT(b.values[off:end:end])
+}
+
+// MarshalBank returns a buffer containing all the LUTs, in order, to be
+// read with NewBank.
+func MarshalBank(luts ...LUT) ([]byte, error) {
+	if len(luts) > math.MaxUint16 {
+		return nil, fmt.Errorf("too many LUTs: %d", len(luts))
+	}
+	off := bankHeaderSize + len(luts)*bankEntrySize
+	size := uint64(off + crcSize)
+	for i, l := range luts {
+		if len(l) < 3 || len(l) > math.MaxUint16 || l[len(l)-1] != 65535 {
+			return nil, fmt.Errorf("LUT %d: invalid LUT", i)
+		}
+		size += 2 * uint64(len(l))
+	}
+	if size > math.MaxInt32 {
+		return nil, errors.New("bank is too large")
+	}
+	b := make([]byte, size)
+	copy(b, bankMagic)
+	b[4] = bankVersion
+	binary.LittleEndian.PutUint16(b[6:], uint16(len(luts)))
+	for i, l := range luts {
+		e := b[bankHeaderSize+i*bankEntrySize:]
+		binary.LittleEndian.PutUint32(e, uint32(off))
+		binary.LittleEndian.PutUint16(e[4:], uint16(len(l)))
+		for _, y := range l {
+			binary.LittleEndian.PutUint16(b[off:], y)
+			off += 2
+		}
+	}
+	binary.LittleEndian.PutUint32(b[off:], crc32.ChecksumIEEE(b[:off]))
+	return b, nil
+}
+
+// isLittleEndian is true when the host is little endian.
+var isLittleEndian = func() bool {
+	v := uint16(1)
+	return *(*byte)(unsafe.Pointer(&v)) == 1
+}()
@@ -0,0 +1,161 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"testing"
+)
+
+func TestBank(t *testing.T) {
+	luts := []LUT{
+		Make(0.25, 0.1, 0.25, 1, 0),
+		MakeFast(0.42, 0, 0.58, 1, 5),
+		{0, 32767, 65535, 65535},
+	}
+	b, err := MarshalBank(luts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 8+3*8+2*(33+6+4)+4 {
+		t.Fatalf("%d", len(b))
+	}
+	bk, err := NewBank(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bk.Len() != 3 {
+		t.Fatal(bk.Len())
+	}
+	for i, l := range luts {
+		got := bk.Get(i)
+		if !reflect.DeepEqual(got, l) {
+			t.Fatalf("#%d: %v != %v", i, got, l)
+		}
+		if cap(got) != len(got) {
+			t.Fatalf("#%d: cap %d", i, cap(got))
+		}
+	}
+	if bk.Get(-1) != nil || bk.Get(3) != nil {
+		t.Fatal("expected nil")
+	}
+	if isLittleEndian {
+		// The LUT aliases the buffer.
+		b[8+3*8+2] = 42
+		b[8+3*8+3] = 0
+		if y := bk.Get(0)[1]; y != 42 {
+			t.Fatal(y)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { bk.Get(1).Eval(1000) }); n != 0 {
+		t.Fatalf("%g allocations", n)
+	}
+}
+
+func TestBank_Unaligned(t *testing.T) {
+	luts := []LUT{Make(0.42, 0, 1, 1, 7), Make(0, 0, 0.58, 1, 3)}
+	b, err := MarshalBank(luts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Force an odd address.
+	buf := make([]byte, len(b)+1)
+	copy(buf[1:], b)
+	bk, err := NewBank(buf[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, l := range luts {
+		if got := bk.Get(i); !reflect.DeepEqual(got, l) {
+			t.Fatalf("#%d: %v != %v", i, got, l)
+		}
+	}
+}
+
+func TestBank_Empty(t *testing.T) {
+	b, err := MarshalBank()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bk, err := NewBank(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bk.Len() != 0 {
+		t.Fatal(bk.Len())
+	}
+}
+
+func TestMarshalBank_Err(t *testing.T) {
+	if _, err := MarshalBank(LUT{0, 65535}); err == nil || err.Error() != "LUT 0: invalid LUT" {
+		t.Fatal(err)
+	}
+	if _, err := MarshalBank(LUT{0, 32767, 65535, 65535}, LUT{0, 1, 2}); err == nil || err.Error() != "LUT 1: invalid LUT" {
+		t.Fatal(err)
+	}
+}
+
+func TestNewBank_Err(t *testing.T) {
+	valid, err := MarshalBank(LUT{0, 32767, 65535, 65535})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// modify changes a copy of valid and updates the CRC.
+	modify := func(f func(b []byte)) []byte {
+		b := append([]byte(nil), valid...)
+		f(b)
+		binary.LittleEndian.PutUint32(b[len(b)-4:], crc32.ChecksumIEEE(b[:len(b)-4]))
+		return b
+	}
+	data := []struct {
+		b   []byte
+		err string
+	}{
+		{nil, "truncated data: got 0 bytes, expected at least 12"},
+		{valid[:14], "truncated data: got 14 bytes, expected at least 20"},
+		{modify(func(b []byte) { b[0] = 'X' }), "invalid magic \"XBZB\", expected \"FBZB\""},
+		{modify(func(b []byte) { b[4] = 2 }), "unsupported version 2, expected 1"},
+		{modify(func(b []byte) { b[5] = 1 }), "invalid reserved byte 0x01"},
+		{modify(func(b []byte) { b[14] = 1 }), "table 0: invalid reserved value 0x0001"},
+		{modify(func(b []byte) { b[8] = 2 }), "table 0: invalid offset 2"},
+		{modify(func(b []byte) { b[8] = 17 }), "table 0: invalid offset 17"},
+		{modify(func(b []byte) { b[12] = 5 }), "table 0: invalid offset 16"},
+		{modify(func(b []byte) { b[12] = 2 }), "table 0: invalid size 2"},
+		{modify(func(b []byte) { b[22] = 0 }), "table 0: last value is 65280, expected 65535"},
+	}
+	for i, line := range data {
+		if _, err := NewBank(line.b); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+	corrupted := append([]byte(nil), valid...)
+	corrupted[16] = 1
+	if _, err := NewBank(corrupted); err == nil || err.Error() != "CRC mismatch: got 0xdbb0167f, expected 0x171a16e1" {
+		t.Fatal(err)
+	}
+}
+
+func ExampleBank() {
+	b, err := MarshalBank(Make(0.42, 0, 1, 1, 0), Make(0, 0, 0.58, 1, 0))
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	// b would normally be stored in flash and read back.
+	bk, err := NewBank(b)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	for i := 0; i < bk.Len(); i++ {
+		fmt.Printf("%d: %d\n", i, bk.Get(i).Eval(32768))
+	}
+	// Output:
+	// 0: 20679
+	// 1: 44858
+}
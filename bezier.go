@@ -8,15 +8,25 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 
 	"github.com/maruel/fastbezier/internal"
 )
 
+// Evaluator exposes a function in the uint16 domain.
+type Evaluator interface {
+	// Eval evaluates a function taking a uint16 as input and returning a
+	// uint16.
+	//
+	// Eval should be idempotent.
+	Eval(x uint16) uint16
+}
+
 // LUT is a fast cubic bezier curve evaluator over uint16 that uses a lookup
 // table.
 //
-// Values are constrained in the range [0, 65535] for both x and y. It forces
-// points (0, 0) and (65535, 65535).
+// Values are constrained in the range [0, 65535] for both x and y. Make and
+// MakeFast force points (0, 0) and (65535, 65535).
 type LUT []uint16
 
 // Make returns a LUT object.
@@ -131,6 +141,52 @@ func MakeFast(x0, y0, x1, y1 float32, steps uint16) LUT {
 	return l
 }
 
+// MakeFunc returns a LUT object sampling an arbitrary function f over
+// [0, 1].
+//
+// f doesn't have to be monotonic. Its values are clamped to [0, 1]. Use
+// MaxError to determine how well the LUT approximates f.
+//
+// Memory allocation is 2*(steps+1) bytes.
+func MakeFunc(f func(x float64) float64, steps uint16) LUT {
+	if steps < 3 {
+		// Make invalid `steps` value silently work instead of crashing or inducing
+		// unnecessary error handling.
+		steps = 32
+	}
+	stepsm1 := 1. / float64(steps-1)
+	l := make(LUT, steps, steps+1)
+	for i := range l {
+		l[i] = float64ToUint16(f(float64(i) * stepsm1))
+	}
+	// Adds a second 65535 to speed up Eval(); otherwise x==65535 has to be
+	// special cased which slows it down.
+	l = append(l, 65535)
+	return l
+}
+
+// MaxError returns the maximum absolute difference between l and f over the
+// whole uint16 domain, and the first x where it happens.
+//
+// f is evaluated over [0, 1] and its values are clamped to [0, 1].
+func (l LUT) MaxError(f func(x float64) float64) (delta, x uint16) {
+	for i := 0; i < 65536; i++ {
+		expected := float64ToUint16(f(float64(i) / 65535.))
+		y := l.Eval(uint16(i))
+		var d uint16
+		if expected < y {
+			d = y - expected
+		} else {
+			d = expected - y
+		}
+		if d > delta {
+			delta = d
+			x = uint16(i)
+		}
+	}
+	return delta, x
+}
+
 func (l LUT) String() string {
 	b := bytes.NewBufferString("LUT{")
 	steps := len(l) - 2
@@ -156,3 +212,32 @@ func (l LUT) Eval(x uint16) uint16 {
 	b := uint32(l[index+1]) * (x32 - baseX)
 	return uint16((a + b) / (nextX - baseX))
 }
+
+// Inverse returns the smallest x for which Eval(x) >= y.
+//
+// The LUT must be monotonically increasing for the result to be meaningful.
+func (l LUT) Inverse(y uint16) uint16 {
+	lo, hi := uint32(0), uint32(65535)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if l.Eval(uint16(mid)) < y {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return uint16(lo)
+}
+
+// float64ToUint16 converts a value in range [0, 1] to the uint16 domain,
+// clamping out of range values.
+func float64ToUint16(y float64) uint16 {
+	y *= 65535.
+	if y <= 0 || y != y {
+		return 0
+	}
+	if y >= 65535 {
+		return 65535
+	}
+	return uint16(math.Floor(y + 0.5))
+}
@@ -6,6 +6,7 @@ package fastbezier
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/maruel/fastbezier/internal"
@@ -69,6 +70,77 @@ func testLUT(t *testing.T, l LUT, x0, y0, x1, y1 float32, maxDelta uint16) {
 	}
 }
 
+func TestLUT_Inverse(t *testing.T) {
+	for _, c := range curves {
+		l := Make(c.x0, c.y0, c.x1, c.y1, 0)
+		for y := 0; y < 65536; y += 7 {
+			x := l.Inverse(uint16(y))
+			if l.Eval(x) < uint16(y) {
+				t.Fatalf("Eval(Inverse(%d)) = %d", y, l.Eval(x))
+			}
+			if x != 0 && l.Eval(x-1) >= uint16(y) {
+				t.Fatalf("Inverse(%d) = %d is not the smallest", y, x)
+			}
+		}
+		if x := l.Inverse(65535); x != 65535 && l.Eval(x) != 65535 {
+			t.Fatalf("Inverse(65535) = %d", x)
+		}
+	}
+}
+
+func TestMakeFunc(t *testing.T) {
+	// Must match Make within the float32 vs float64 rounding.
+	for _, c := range curves {
+		f := func(x float64) float64 {
+			return float64(internal.CubicBezier(c.x0, c.y0, c.x1, c.y1, float32(x)))
+		}
+		l := MakeFunc(f, 0)
+		if delta, x := l.MaxError(f); delta > 104 {
+			t.Errorf("%v: delta=%d at x=%d", c, delta, x)
+		}
+		m := Make(c.x0, c.y0, c.x1, c.y1, 0)
+		for i := range l {
+			if d := int(l[i]) - int(m[i]); d < -1 || d > 1 {
+				t.Errorf("%v: %s != %s", c, l, m)
+				break
+			}
+		}
+	}
+
+	// Non-monotonic and out of range values.
+	l := MakeFunc(func(x float64) float64 { return 2 * math.Sin(2*math.Pi*x) }, 5)
+	if s := l.String(); s != "LUT{(0, 0), (16383, 65535), (32767, 0), (49151, 0), (65535, 0)}" {
+		t.Fatal(s)
+	}
+}
+
+func TestLUT_MaxError(t *testing.T) {
+	l := LUT{0, 32768, 65535, 65535}
+	if delta, x := l.MaxError(func(x float64) float64 { return x }); delta != 1 || x != 32767 {
+		t.Fatalf("delta=%d x=%d", delta, x)
+	}
+	if delta, x := l.MaxError(func(x float64) float64 { return 0 }); delta != 65535 || x != 65535 {
+		t.Fatalf("delta=%d x=%d", delta, x)
+	}
+}
+
+func ExampleMakeFunc() {
+	// Logarithmic volume curve with a 60dB range.
+	volume := func(x float64) float64 {
+		if x == 0 {
+			return 0
+		}
+		return math.Pow(10, 3*(x-1))
+	}
+	l := MakeFunc(volume, 8)
+	fmt.Printf("%s\n", l)
+	delta, x := l.MaxError(volume)
+	fmt.Printf("max error %d at x=%d\n", delta, x)
+	// Output:
+	// LUT{(0, 0), (9362, 176), (18724, 472), (28086, 1265), (37448, 3394), (46810, 9106), (56172, 24429), (65535, 65535)}
+	// max error 5005 at x=61179
+}
+
 func ExampleMake() {
 	l := Make(0, 0, 0.58, 1, 6)
 	fmt.Printf("%s\n", l)
@@ -124,7 +196,7 @@ func ExampleLUT_Eval() {
 	//  13 1.000 65535 1.000 65535 65535     0  0.000%
 }
 
-func ExampleLUT_Eval_Fast() {
+func ExampleLUT_Eval_fast() {
 	const steps = 14
 	l := MakeFast(0.42, 0, 0.58, 1, 0)
 	fmt.Println("  i    xf    xi   yfi    yf    yi delta   error")
@@ -0,0 +1,135 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+)
+
+// Binary format of a LUT, all values are little endian:
+//
+//   - magic "FBZL"
+//   - version, 1 byte
+//   - flags, 1 byte; bit 0 set when the curve parameters are present
+//   - number of points N, uint16; it excludes the trailing 65535
+//   - x0, y0, x1, y1 as float32, only when flag bit 0 is set
+//   - N uint16 values
+//   - CRC32 (IEEE) of all the preceding bytes, uint32
+const (
+	binaryMagic   = "FBZL"
+	binaryVersion = 1
+	flagParams    = 1
+	// headerSize is magic + version + flags + N.
+	headerSize = 4 + 1 + 1 + 2
+	paramsSize = 4 * 4
+	crcSize    = 4
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The curve parameters are not stored; use MarshalBinaryParams to store
+// them.
+func (l LUT) MarshalBinary() ([]byte, error) {
+	return l.marshalBinary(nil)
+}
+
+// MarshalBinaryParams is the same as MarshalBinary but also stores the
+// parameters of the curve that was used to generate the LUT.
+func (l LUT) MarshalBinaryParams(x0, y0, x1, y1 float32) ([]byte, error) {
+	return l.marshalBinary([]float32{x0, y0, x1, y1})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// It accepts data with or without curve parameters.
+func (l *LUT) UnmarshalBinary(b []byte) error {
+	_, err := l.UnmarshalBinaryParams(b)
+	return err
+}
+
+// UnmarshalBinaryParams is the same as UnmarshalBinary but also returns the
+// curve parameters x0, y0, x1, y1 if they were stored, nil otherwise.
+func (l *LUT) UnmarshalBinaryParams(b []byte) ([]float32, error) {
+	if len(b) < headerSize+crcSize {
+		return nil, fmt.Errorf("truncated data: got %d bytes, expected at least %d", len(b), headerSize+crcSize)
+	}
+	if string(b[:4]) != binaryMagic {
+		return nil, fmt.Errorf("invalid magic %q, expected %q", b[:4], binaryMagic)
+	}
+	if b[4] != binaryVersion {
+		return nil, fmt.Errorf("unsupported version %d, expected %d", b[4], binaryVersion)
+	}
+	flags := b[5]
+	if flags&^flagParams != 0 {
+		return nil, fmt.Errorf("unknown flags 0x%02x", flags)
+	}
+	n := int(binary.LittleEndian.Uint16(b[6:]))
+	if n < 2 {
+		return nil, fmt.Errorf("invalid number of points %d, expected at least 2", n)
+	}
+	size := headerSize + 2*n + crcSize
+	if flags&flagParams != 0 {
+		size += paramsSize
+	}
+	if len(b) < size {
+		return nil, fmt.Errorf("truncated data: got %d bytes, expected %d", len(b), size)
+	}
+	if len(b) > size {
+		return nil, fmt.Errorf("%d bytes of trailing data", len(b)-size)
+	}
+	if got, want := crc32.ChecksumIEEE(b[:size-crcSize]), binary.LittleEndian.Uint32(b[size-crcSize:]); got != want {
+		return nil, fmt.Errorf("CRC mismatch: got 0x%08x, expected 0x%08x", got, want)
+	}
+	b = b[headerSize:]
+	var params []float32
+	if flags&flagParams != 0 {
+		params = make([]float32, 4)
+		for i := range params {
+			params[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+		}
+		b = b[paramsSize:]
+	}
+	out := make(LUT, n, n+1)
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	// Adds a second 65535 to speed up Eval(); otherwise x==65535 has to be
+	// special cased which slows it down.
+	*l = append(out, 65535)
+	return params, nil
+}
+
+func (l LUT) marshalBinary(params []float32) ([]byte, error) {
+	// Do not store the trailing 65535.
+	n := len(l) - 1
+	if n < 2 || n > math.MaxUint16 {
+		return nil, errors.New("invalid LUT")
+	}
+	size := headerSize + 2*n + crcSize
+	flags := byte(0)
+	if params != nil {
+		size += paramsSize
+		flags |= flagParams
+	}
+	b := make([]byte, 0, size)
+	b = append(b, binaryMagic...)
+	b = append(b, binaryVersion, flags, 0, 0)
+	binary.LittleEndian.PutUint16(b[6:], uint16(n))
+	var tmp [4]byte
+	for _, p := range params {
+		binary.LittleEndian.PutUint32(tmp[:], math.Float32bits(p))
+		b = append(b, tmp[:]...)
+	}
+	for _, y := range l[:n] {
+		binary.LittleEndian.PutUint16(tmp[:], y)
+		b = append(b, tmp[:2]...)
+	}
+	binary.LittleEndian.PutUint32(tmp[:], crc32.ChecksumIEEE(b))
+	return append(b, tmp[:]...), nil
+}
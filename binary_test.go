@@ -0,0 +1,125 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+var _ encoding.BinaryMarshaler = LUT{}
+var _ encoding.BinaryUnmarshaler = &LUT{}
+
+func TestLUT_MarshalBinary(t *testing.T) {
+	l := LUT{0, 32767, 65535, 65535}
+	b, err := l.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		'F', 'B', 'Z', 'L', 1, 0, 3, 0,
+		0, 0, 0xff, 0x7f, 0xff, 0xff,
+	}
+	if !reflect.DeepEqual(b[:len(b)-4], expected) {
+		t.Fatalf("%v", b)
+	}
+	var got LUT
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, l) {
+		t.Fatalf("%v != %v", got, l)
+	}
+	params, err := got.UnmarshalBinaryParams(b)
+	if err != nil || params != nil {
+		t.Fatal(params, err)
+	}
+}
+
+func TestLUT_MarshalBinaryParams(t *testing.T) {
+	for _, c := range curves {
+		l := Make(c.x0, c.y0, c.x1, c.y1, 0)
+		b, err := l.MarshalBinaryParams(c.x0, c.y0, c.x1, c.y1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b) != 8+16+2*32+4 {
+			t.Fatalf("%d", len(b))
+		}
+		var got LUT
+		params, err := got.UnmarshalBinaryParams(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, l) {
+			t.Fatalf("%v != %v", got, l)
+		}
+		if expected := []float32{c.x0, c.y0, c.x1, c.y1}; !reflect.DeepEqual(params, expected) {
+			t.Fatalf("%v != %v", params, expected)
+		}
+		// Params are ignored by UnmarshalBinary.
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLUT_MarshalBinary_Err(t *testing.T) {
+	if _, err := (LUT{0, 65535}).MarshalBinary(); err == nil || err.Error() != "invalid LUT" {
+		t.Fatal(err)
+	}
+	valid, err := (LUT{0, 32767, 65535, 65535}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modify := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte(nil), valid...))
+	}
+	data := []struct {
+		b   []byte
+		err string
+	}{
+		{nil, "truncated data: got 0 bytes, expected at least 12"},
+		{valid[:len(valid)-1], "truncated data: got 17 bytes, expected 18"},
+		{append(append([]byte(nil), valid...), 0), "1 bytes of trailing data"},
+		{modify(func(b []byte) []byte { b[0] = 'X'; return b }), "invalid magic \"XBZL\", expected \"FBZL\""},
+		{modify(func(b []byte) []byte { b[4] = 2; return b }), "unsupported version 2, expected 1"},
+		{modify(func(b []byte) []byte { b[5] = 2; return b }), "unknown flags 0x02"},
+		{modify(func(b []byte) []byte { b[6] = 1; return b }), "invalid number of points 1, expected at least 2"},
+		{modify(func(b []byte) []byte { b[5] = 1; return b }), "truncated data: got 18 bytes, expected 34"},
+		{modify(func(b []byte) []byte { b[9] = 1; return b }), "CRC mismatch: got 0xdcaca62b, expected 0xe1cc8f9b"},
+	}
+	for i, line := range data {
+		var l LUT
+		if err := l.UnmarshalBinary(line.b); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+		if l != nil {
+			t.Errorf("#%d: LUT was modified", i)
+		}
+	}
+}
+
+func ExampleLUT_MarshalBinaryParams() {
+	l := Make(0.42, 0, 0.58, 1, 5)
+	b, err := l.MarshalBinaryParams(0.42, 0, 0.58, 1)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	var l2 LUT
+	params, err := l2.UnmarshalBinaryParams(b)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("%d bytes\n%v\n%s\n", len(b), params, l2)
+	// Output:
+	// 38 bytes
+	// [0.42 0 0.58 1]
+	// LUT{(0, 0), (16383, 8465), (32767, 32768), (49151, 57070), (65535, 65535)}
+}
@@ -5,49 +5,226 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/maruel/fastbezier"
 )
 
-func mainImpl() error {
-	flag.Parse()
+// formats are the supported output formats.
+var formats = []string{"text", "go", "c", "json", "csv", "binary", "bank", "cbank", "arduino", "rust", "coe", "mif", "mem", "verilog"}
 
-	if flag.NArg() != 5 {
-		return errors.New("supply 5 values")
+// write writes the tables in the specified format.
+func write(w io.Writer, format, pkg string, width int, entries []entry) error {
+	tables := make([]fastbezier.Table, len(entries))
+	for i, e := range entries {
+		tables[i] = e.t
 	}
-	x0, err := strconv.ParseFloat(flag.Arg(0), 64)
-	if err != nil {
+	switch format {
+	case "text":
+		for _, t := range tables {
+			var err error
+			if len(tables) == 1 {
+				_, err = fmt.Fprintf(w, "%s\n", t.LUT)
+			} else {
+				_, err = fmt.Fprintf(w, "%s: %s\n", t.Name, t.LUT)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case "go":
+		return fastbezier.WriteGo(w, pkg, tables...)
+	case "c":
+		return fastbezier.WriteC(w, tables...)
+	case "json":
+		type table struct {
+			Name   string   `json:"name"`
+			Desc   string   `json:"desc"`
+			Values []uint16 `json:"values"`
+		}
+		out := make([]table, len(tables))
+		for i, t := range tables {
+			// Do not include the trailing 65535.
+			out[i] = table{t.Name, t.Desc, t.LUT[:len(t.LUT)-1]}
+		}
+		b, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
 		return err
-	}
-	y0, err := strconv.ParseFloat(flag.Arg(1), 64)
-	if err != nil {
+	case "csv":
+		if _, err := io.WriteString(w, "name,x,y\n"); err != nil {
+			return err
+		}
+		for _, t := range tables {
+			steps := len(t.LUT) - 2
+			for i, y := range t.LUT[:steps+1] {
+				if _, err := fmt.Fprintf(w, "%s,%d,%d\n", t.Name, i*65535/steps, y); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case "binary":
+		if len(entries) != 1 {
+			return errors.New("binary format supports a single curve; use bank instead")
+		}
+		c := entries[0].c
+		b, err := tables[0].LUT.MarshalBinaryParams(c.X0, c.Y0, c.X1, c.Y1)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
 		return err
-	}
-	x1, err := strconv.ParseFloat(flag.Arg(2), 64)
-	if err != nil {
+	case "bank":
+		luts := make([]fastbezier.LUT, len(tables))
+		for i, t := range tables {
+			luts[i] = t.LUT
+		}
+		b, err := fastbezier.MarshalBank(luts...)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
 		return err
+	case "cbank":
+		return fastbezier.WriteCBank(w, tables...)
+	case "rust":
+		return fastbezier.WriteRust(w, tables...)
+	case "coe", "mif", "mem", "verilog":
+		if len(tables) != 1 {
+			return fmt.Errorf("%s format supports a single curve", format)
+		}
+		switch format {
+		case "coe":
+			return fastbezier.WriteCOE(w, tables[0], width)
+		case "mif":
+			return fastbezier.WriteMIF(w, tables[0], width)
+		case "mem":
+			return fastbezier.WriteMem(w, tables[0], width)
+		default:
+			return fastbezier.WriteVerilog(w, tables[0], tables[0].Name+".mem")
+		}
+	default:
+		return fmt.Errorf("unknown format %q; supported formats are %s", format, strings.Join(formats, ", "))
 	}
-	y1, err := strconv.ParseFloat(flag.Arg(3), 64)
-	if err != nil {
-		return err
+}
+
+func mainImpl() error {
+	var curve fastbezier.Easing
+	flag.Var(&curve, "curve", "CSS easing function, e.g. ease-in-out or \"cubic-bezier(0.42, 0, 0.58, 1)\"")
+	fast := flag.Bool("fast", false, "use MakeFast instead of Make")
+	format := flag.String("format", "text", "output format; one of "+strings.Join(formats, ", "))
+	name := flag.String("name", "Curve", "variable or symbol name")
+	pkg := flag.String("pkg", "main", "package name, for -format go")
+	width := flag.Int("width", 16, "ROM word width in bits, for -format coe, mif and mem")
+	out := flag.String("o", "", "output file; defaults to stdout. For -format arduino, the library directory")
+	manifest := flag.String("manifest", "", "manifest file listing the curves to generate, either JSON or one \"<name> <curve> <steps> [fast]\" per line")
+	flag.Parse()
+
+	curveSet := false
+	flag.Visit(func(f *flag.Flag) {
+		curveSet = curveSet || f.Name == "curve"
+	})
+	var c fastbezier.Curve
+	steps := 0
+	var entries []entry
+	if *manifest != "" {
+		if curveSet || flag.NArg() != 0 {
+			return errors.New("do not supply -curve or values with -manifest")
+		}
+		b, err := ioutil.ReadFile(*manifest)
+		if err != nil {
+			return err
+		}
+		if entries, err = parseManifest(b); err != nil {
+			return fmt.Errorf("%s: %v", *manifest, err)
+		}
+	} else if curveSet {
+		switch flag.NArg() {
+		case 0:
+		case 1:
+			var err error
+			if steps, err = strconv.Atoi(flag.Arg(0)); err != nil {
+				return err
+			}
+			if err = checkSteps(steps); err != nil {
+				return err
+			}
+		default:
+			return errors.New("supply at most 1 value with -curve")
+		}
+		if curve.Steps.N != 0 {
+			return fmt.Errorf("%s cannot be represented as a LUT", curve)
+		}
+		c = fastbezier.Curve{X0: curve.X0, Y0: curve.Y0, X1: curve.X1, Y1: curve.Y1}
+	} else {
+		if flag.NArg() != 5 {
+			return errors.New("supply 5 values")
+		}
+		x0, err := strconv.ParseFloat(flag.Arg(0), 64)
+		if err != nil {
+			return err
+		}
+		y0, err := strconv.ParseFloat(flag.Arg(1), 64)
+		if err != nil {
+			return err
+		}
+		x1, err := strconv.ParseFloat(flag.Arg(2), 64)
+		if err != nil {
+			return err
+		}
+		y1, err := strconv.ParseFloat(flag.Arg(3), 64)
+		if err != nil {
+			return err
+		}
+		if steps, err = strconv.Atoi(flag.Arg(4)); err != nil {
+			return err
+		}
+		if err = checkSteps(steps); err != nil {
+			return err
+		}
+		c = fastbezier.Curve{X0: float32(x0), Y0: float32(y0), X1: float32(x1), Y1: float32(y1)}
 	}
-	steps, err := strconv.Atoi(flag.Arg(4))
-	if err != nil {
+	if entries == nil {
+		entries = []entry{{c: c, t: fastbezier.NewTable(*name, c, uint16(steps), *fast)}}
+	}
+	if *format == "arduino" {
+		if *out == "" {
+			return errors.New("-o is required with -format arduino")
+		}
+		tables := make([]fastbezier.Table, len(entries))
+		for i, e := range entries {
+			tables[i] = e.t
+		}
+		return fastbezier.WriteArduino(*out, tables...)
+	}
+	// Generate everything first so a partial file is never written.
+	var b bytes.Buffer
+	if err := write(&b, *format, *pkg, *width, entries); err != nil {
 		return err
 	}
-	f := fastbezier.Make(float32(x0), float32(y0), float32(x1), float32(y1), uint16(steps))
-	_, err = fmt.Printf("%s\n", f)
+	if *out != "" {
+		return ioutil.WriteFile(*out, b.Bytes(), 0644)
+	}
+	_, err := os.Stdout.Write(b.Bytes())
 	return err
 }
 
 func main() {
 	if err := mainImpl(); err != nil {
-		fmt.Fprintf(os.Stderr, "usage: makebezier <x0> <y0> <x1> <y1> <steps>\nmakebezier: %s.\n", err)
+		fmt.Fprintf(os.Stderr, "usage: makebezier [-fast] [-format <format>] [-name <name>] [-pkg <pkg>] [-width <bits>] [-o <file>] <x0> <y0> <x1> <y1> <steps>\n       makebezier [flags] -curve <easing> [steps]\n       makebezier [flags] -manifest <file>\nmakebezier: %s.\n", err)
 		os.Exit(1)
 	}
 }
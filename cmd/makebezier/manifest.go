@@ -0,0 +1,173 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/maruel/fastbezier"
+)
+
+// entry is a curve to generate.
+type entry struct {
+	c fastbezier.Curve
+	t fastbezier.Table
+	// line is the line number in the manifest, 0 when not from a manifest.
+	line int
+}
+
+// parseManifest parses a manifest, either a JSON array or a line oriented
+// file.
+//
+// The line oriented format is one curve per line:
+//
+//	<name> <curve> <steps> [fast]
+//
+// where <curve> is a CSS easing function. Empty lines and lines starting
+// with # are ignored.
+//
+// The JSON format is an array of objects:
+//
+//	[{"name": "EaseIn", "curve": "ease-in", "steps": 32, "fast": false}]
+//
+// where "curve" can also be an array of 4 numbers [x0, y0, x1, y1].
+//
+// Errors are prefixed with the line number and, for invalid curves in the
+// line oriented format, the column.
+func parseManifest(b []byte) ([]entry, error) {
+	var entries []entry
+	var err error
+	if t := bytes.TrimSpace(b); len(t) != 0 && t[0] == '[' {
+		entries, err = parseJSONManifest(b)
+	} else {
+		entries, err = parseTextManifest(string(b))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("manifest is empty")
+	}
+	seen := map[string]int{}
+	for _, e := range entries {
+		if err := e.t.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %v", e.line, err)
+		}
+		if l, ok := seen[e.t.Name]; ok {
+			return nil, fmt.Errorf("line %d: duplicate name %q, first defined at line %d", e.line, e.t.Name, l)
+		}
+		seen[e.t.Name] = e.line
+	}
+	return entries, nil
+}
+
+func parseTextManifest(s string) ([]entry, error) {
+	var out []entry
+	for i, line := range strings.Split(s, "\n") {
+		lineno := i + 1
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		// The curve may contain spaces, e.g. "cubic-bezier(0, 0, 1, 1)".
+		name := strings.Fields(line)[0]
+		rest := strings.TrimLeft(line[len(name):], " \t")
+		col := len(line) - len(rest) + 1
+		end := strings.IndexAny(rest, " \t")
+		if p := strings.IndexByte(rest, '('); p != -1 && (end == -1 || p < end) {
+			if end = strings.IndexByte(rest, ')'); end != -1 {
+				end++
+			}
+		}
+		if end == -1 {
+			end = len(rest)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("line %d: expected <name> <curve> <steps> [fast]", lineno)
+		}
+		e, err := fastbezier.ParseEasing(rest[:end])
+		if err != nil {
+			if p, ok := err.(*fastbezier.ParseError); ok {
+				return nil, fmt.Errorf("line %d:%d: %s", lineno, col+p.Column-1, p.Msg)
+			}
+			return nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+		if e.Steps.N != 0 {
+			return nil, fmt.Errorf("line %d: %s cannot be represented as a LUT", lineno, e)
+		}
+		fields := strings.Fields(rest[end:])
+		if len(fields) == 0 || len(fields) > 2 || len(fields) == 2 && fields[1] != "fast" {
+			return nil, fmt.Errorf("line %d: expected <name> <curve> <steps> [fast]", lineno)
+		}
+		steps, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid steps %q", lineno, fields[0])
+		}
+		if err := checkSteps(steps); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+		c := fastbezier.Curve{X0: e.X0, Y0: e.Y0, X1: e.X1, Y1: e.Y1}
+		out = append(out, entry{c, fastbezier.NewTable(name, c, uint16(steps), len(fields) == 2), lineno})
+	}
+	return out, nil
+}
+
+func parseJSONManifest(b []byte) ([]entry, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		if s, ok := err.(*json.SyntaxError); ok {
+			return nil, fmt.Errorf("line %d: %v", lineAt(b, int(s.Offset)), err)
+		}
+		return nil, err
+	}
+	var out []entry
+	off := 0
+	for _, r := range raw {
+		// RawMessage are verbatim copies so they can be found in order to
+		// calculate the line number.
+		off += bytes.Index(b[off:], r)
+		lineno := lineAt(b, off)
+		off += len(r)
+		var v struct {
+			Name  string
+			Curve *fastbezier.Curve
+			Steps *int
+			Fast  bool
+		}
+		d := json.NewDecoder(bytes.NewReader(r))
+		d.DisallowUnknownFields()
+		if err := d.Decode(&v); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+		if v.Curve == nil {
+			return nil, fmt.Errorf("line %d: \"curve\" is required", lineno)
+		}
+		if v.Steps == nil {
+			return nil, fmt.Errorf("line %d: \"steps\" is required", lineno)
+		}
+		if err := checkSteps(*v.Steps); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+		out = append(out, entry{*v.Curve, fastbezier.NewTable(v.Name, *v.Curve, uint16(*v.Steps), v.Fast), lineno})
+	}
+	return out, nil
+}
+
+func checkSteps(steps int) error {
+	if steps < 3 || steps > 65534 {
+		return fmt.Errorf("steps must be in range [3, 65534], got %d", steps)
+	}
+	return nil
+}
+
+// lineAt returns the 1 based line number at offset off.
+func lineAt(b []byte, off int) int {
+	return 1 + bytes.Count(b[:off], []byte{'\n'})
+}
@@ -0,0 +1,80 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maruel/fastbezier"
+)
+
+func TestParseManifest(t *testing.T) {
+	data := []string{
+		"# Comment.\n\nEaseIn ease-in 32\nCustom cubic-bezier(0.1, 0.2, 0.3, 0.4)\t8\n",
+		"[\n  {\"name\": \"EaseIn\", \"curve\": \"ease-in\", \"steps\": 32},\n  {\"name\": \"Custom\", \"curve\": [0.1, 0.2, 0.3, 0.4], \"steps\": 8, \"fast\": false}\n]\n",
+	}
+	for i, s := range data {
+		entries, err := parseManifest([]byte(s))
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("#%d: %v", i, entries)
+		}
+		expected := []entry{
+			{fastbezier.Curve{X0: 0.42, Y0: 0, X1: 1, Y1: 1}, fastbezier.NewTable("EaseIn", fastbezier.Curve{X0: 0.42, Y0: 0, X1: 1, Y1: 1}, 32, false), 3},
+			{fastbezier.Curve{X0: 0.1, Y0: 0.2, X1: 0.3, Y1: 0.4}, fastbezier.NewTable("Custom", fastbezier.Curve{X0: 0.1, Y0: 0.2, X1: 0.3, Y1: 0.4}, 8, false), 4},
+		}
+		if i == 1 {
+			expected[0].line = 2
+			expected[1].line = 3
+		}
+		for j, e := range entries {
+			if fmt.Sprint(e) != fmt.Sprint(expected[j]) {
+				t.Errorf("#%d: entry %d\n%v\n%v", i, j, e, expected[j])
+			}
+		}
+	}
+}
+
+func TestParseManifest_Err(t *testing.T) {
+	data := []struct {
+		s   string
+		err string
+	}{
+		{"", "manifest is empty"},
+		{"# Only a comment.\n", "manifest is empty"},
+		{"[]", "manifest is empty"},
+		// Line oriented.
+		{"A ease-in 32\n\nA ease-out 32\n", "line 3: duplicate name \"A\", first defined at line 1"},
+		{"1A ease-in 32\n", "line 1: invalid name \"1A\""},
+		{"A cubic-bezier(0.1, 0, 2, 1) 32\n", "line 1:24: x1 must be in range [0, 1], got 2"},
+		{"A  cubic-bezier(0.1, 0, foo, 1) 32\n", "line 1:25: expected a number"},
+		{"A steps(4) 32\n", "line 1: steps(4) cannot be represented as a LUT"},
+		{"A ease-in 2\n", "line 1: steps must be in range [3, 65534], got 2"},
+		{"A ease-in 65535\n", "line 1: steps must be in range [3, 65534], got 65535"},
+		{"A ease-in x\n", "line 1: invalid steps \"x\""},
+		{"A ease-in\n", "line 1: expected <name> <curve> <steps> [fast]"},
+		{"A ease-in 32 slow\n", "line 1: expected <name> <curve> <steps> [fast]"},
+		{"A\n", "line 1: expected <name> <curve> <steps> [fast]"},
+		// JSON.
+		{"[\n{\"name\": \"A\", \"curve\": \"ease-in\", \"steps\": 32},\n{\"name\": \"A\", \"curve\": \"ease-out\", \"steps\": 32}]", "line 3: duplicate name \"A\", first defined at line 2"},
+		{"[{\"name\": \"a-b\", \"curve\": \"ease-in\", \"steps\": 32}]", "line 1: invalid name \"a-b\""},
+		{"[\n\n{\"name\": \"A\", \"curve\": [0.1, 0, 2, 1], \"steps\": 32}]", "line 3: x1 must be in range [0, 1], got 2"},
+		{"[{\"name\": \"A\", \"curve\": \"steps(4)\", \"steps\": 32}]", "line 1: steps(4) is not a cubic bezier curve"},
+		{"[{\"name\": \"A\", \"steps\": 32}]", "line 1: \"curve\" is required"},
+		{"[{\"name\": \"A\", \"curve\": \"ease-in\"}]", "line 1: \"steps\" is required"},
+		{"[{\"name\": \"A\", \"curve\": \"ease-in\", \"steps\": 2}]", "line 1: steps must be in range [3, 65534], got 2"},
+		{"[{\"name\": \"A\", \"curve\": \"ease-in\", \"steps\": 65535}]", "line 1: steps must be in range [3, 65534], got 65535"},
+		{"[{\"name\": \"A\", \"curve\": \"ease-in\", \"steps\": 32, \"foo\": 1}]", "line 1: json: unknown field \"foo\""},
+		{"[\n{\"name\": \"A\",,}]", "line 2: invalid character ',' looking for beginning of object key string"},
+	}
+	for i, line := range data {
+		if _, err := parseManifest([]byte(line.s)); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"sync"
+	"time"
+)
+
+// Controller is an animation following a LUT that can be paused, moved,
+// reversed and sped up while it runs.
+//
+// The animation goes from 0 to 65535 and back to 0 when reversed. Each pass
+// follows the curve from its start, so reversing an ease-in animation eases
+// in toward 0.
+//
+// All methods are safe to call concurrently.
+type Controller struct {
+	mu       sync.Mutex
+	l        LUT
+	d        time.Duration
+	anchor   time.Time // Time at which pos was last updated.
+	pos      float64   // Position in the current pass, in [0, 1].
+	speed    float64
+	paused   bool
+	reversed bool
+}
+
+// NewController returns a Controller that starts right away and runs over
+// the duration d at normal speed.
+func NewController(l LUT, d time.Duration) *Controller {
+	return &Controller{l: l, d: d, anchor: timeNow(), speed: 1}
+}
+
+// Value returns the current value.
+func (c *Controller) Value() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update()
+	return c.value()
+}
+
+// Done returns true when the current pass completed.
+func (c *Controller) Done() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update()
+	return c.pos >= 1
+}
+
+// Pause freezes the animation at its current value.
+func (c *Controller) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update()
+	c.paused = true
+}
+
+// Resume resumes a paused animation.
+func (c *Controller) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update()
+	c.paused = false
+}
+
+// Seek moves to fraction of the current pass, where 0 is the start of the
+// pass and 1 its end.
+//
+// When reversed, 0 means 65535 and 1 means 0.
+func (c *Controller) Seek(fraction float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update()
+	c.pos = clamp01(fraction)
+}
+
+// Reverse changes the direction of the animation without a jump in the
+// output.
+//
+// The new pass starts at the point on the curve that evaluates to the current
+// value, so the remaining duration depends on the shape of the curve.
+func (c *Controller) Reverse() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update()
+	v := c.value()
+	c.reversed = !c.reversed
+	if c.reversed {
+		v = 65535 - v
+	}
+	c.pos = float64(c.l.Inverse(v)) / 65535.
+}
+
+// SetSpeed sets the playback speed, where 1 is normal speed.
+//
+// Negative values are treated as 0. Use Reverse to go backward.
+func (c *Controller) SetSpeed(speed float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update()
+	if speed < 0 {
+		speed = 0
+	}
+	c.speed = speed
+}
+
+// update folds the time elapsed since the last update into pos.
+func (c *Controller) update() {
+	now := timeNow()
+	if !c.paused && c.d > 0 {
+		c.pos = clamp01(c.pos + float64(now.Sub(c.anchor))/float64(c.d)*c.speed)
+	} else if c.d <= 0 {
+		c.pos = 1
+	}
+	c.anchor = now
+}
+
+func (c *Controller) value() uint16 {
+	y := c.l.Eval(uint16(c.pos*65535. + 0.5))
+	if c.reversed {
+		return 65535 - y
+	}
+	return y
+}
+
+func clamp01(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	if x > 1 {
+		return 1
+	}
+	return x
+}
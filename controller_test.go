@@ -0,0 +1,138 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestController(t *testing.T) {
+	now, restore := fakeNow()
+	defer restore()
+	l := Make(0.42, 0, 0.58, 1, 0)
+	c := NewController(l, time.Second)
+	if v := c.Value(); v != 0 {
+		t.Fatalf("unexpected start %d", v)
+	}
+	*now = now.Add(250 * time.Millisecond)
+	if v := c.Value(); v != l.Eval(16384) {
+		t.Fatalf("%d != %d", v, l.Eval(16384))
+	}
+
+	c.Pause()
+	*now = now.Add(time.Hour)
+	if v := c.Value(); v != l.Eval(16384) {
+		t.Fatalf("paused: %d != %d", v, l.Eval(16384))
+	}
+	c.Resume()
+
+	c.SetSpeed(2)
+	*now = now.Add(250 * time.Millisecond)
+	if v := c.Value(); v != l.Eval(49151) {
+		t.Fatalf("speed: %d != %d", v, l.Eval(49151))
+	}
+	c.SetSpeed(-1)
+	*now = now.Add(250 * time.Millisecond)
+	if v := c.Value(); v != l.Eval(49151) {
+		t.Fatalf("speed: %d != %d", v, l.Eval(49151))
+	}
+	c.SetSpeed(1)
+
+	c.Seek(0.5)
+	if v := c.Value(); v != l.Eval(32768) {
+		t.Fatalf("seek: %d != %d", v, l.Eval(32768))
+	}
+	if c.Done() {
+		t.Fatal("unexpected done")
+	}
+	*now = now.Add(time.Second)
+	if v := c.Value(); v != 65535 {
+		t.Fatalf("end: %d", v)
+	}
+	if !c.Done() {
+		t.Fatal("expected done")
+	}
+}
+
+func TestController_Reverse(t *testing.T) {
+	now, restore := fakeNow()
+	defer restore()
+	for _, curve := range curves {
+		l := Make(curve.x0, curve.y0, curve.x1, curve.y1, 0)
+		c := NewController(l, time.Second)
+		*now = now.Add(300 * time.Millisecond)
+		before := c.Value()
+		c.Reverse()
+		after := c.Value()
+		if after > before || before-after > 2 {
+			t.Fatalf("%v: discontinuity %d -> %d", curve, before, after)
+		}
+		// It must go down monotonically until it reaches 0.
+		last := after
+		for i := 0; i < 100; i++ {
+			*now = now.Add(10 * time.Millisecond)
+			v := c.Value()
+			if v > last {
+				t.Fatalf("%v: went up %d -> %d", curve, last, v)
+			}
+			last = v
+		}
+		if last != 0 || !c.Done() {
+			t.Fatalf("%v: expected to be back to 0, got %d", curve, last)
+		}
+
+		// Reverse again.
+		c.Seek(0.5)
+		before = c.Value()
+		c.Reverse()
+		after = c.Value()
+		if after < before || after-before > 2 {
+			t.Fatalf("%v: discontinuity %d -> %d", curve, before, after)
+		}
+		*now = now.Add(time.Second)
+		if v := c.Value(); v != 65535 {
+			t.Fatalf("%v: expected 65535, got %d", curve, v)
+		}
+	}
+}
+
+func TestController_Concurrent(t *testing.T) {
+	c := NewController(Make(0.42, 0, 0.58, 1, 0), time.Millisecond)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				switch (i + j) % 5 {
+				case 0:
+					c.Pause()
+				case 1:
+					c.Resume()
+				case 2:
+					c.Seek(0.1)
+				case 3:
+					c.Reverse()
+				case 4:
+					c.SetSpeed(1.5)
+				}
+				c.Value()
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// fakeNow replaces the clock until the returned function is called.
+func fakeNow() (*time.Time, func()) {
+	now := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := timeNow
+	timeNow = func() time.Time { return now }
+	return &now, func() {
+		timeNow = old
+	}
+}
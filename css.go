@@ -0,0 +1,309 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Easing is a CSS <easing-function>, either a cubic bezier curve or steps.
+//
+// It implements encoding.TextMarshaler, encoding.TextUnmarshaler and
+// flag.Value so it can be used directly in configuration files and command
+// line flags.
+type Easing struct {
+	// X0, Y0, X1, Y1 are the cubic-bezier() parameters. They are used when
+	// Steps.N is 0.
+	X0, Y0, X1, Y1 float32
+	// Steps is set for steps() easing functions.
+	Steps Steps
+}
+
+// ParseEasing parses a CSS easing function.
+//
+// It accepts the keywords linear, ease, ease-in, ease-out, ease-in-out,
+// step-start and step-end, and the functions cubic-bezier() and steps().
+//
+// Errors are of type *ParseError.
+func ParseEasing(s string) (Easing, error) {
+	p := scanner{s: s}
+	e, err := p.easing()
+	if err != nil {
+		return Easing{}, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return Easing{}, p.errorf(p.pos, "unexpected %q", p.s[p.pos:])
+	}
+	return e, nil
+}
+
+// String returns the CSS representation, using a keyword when possible.
+func (e Easing) String() string {
+	for _, k := range cssKeywords {
+		if k.e == e {
+			return k.name
+		}
+	}
+	if e.Steps.N != 0 {
+		return e.Steps.String()
+	}
+	return fmt.Sprintf("cubic-bezier(%g, %g, %g, %g)", e.X0, e.Y0, e.X1, e.Y1)
+}
+
+// Evaluator returns an evaluator for the easing function.
+//
+// Cubic bezier curves are returned as a LUT of `steps` points.
+func (e Easing) Evaluator(steps uint16) Evaluator {
+	if e.Steps.N != 0 {
+		return e.Steps
+	}
+	return Make(e.X0, e.Y0, e.X1, e.Y1, steps)
+}
+
+// LUT returns a LUT for a cubic bezier easing function.
+//
+// It returns an error for steps() easing functions since they cannot be
+// represented by a LUT.
+func (e Easing) LUT(steps uint16) (LUT, error) {
+	if e.Steps.N != 0 {
+		return nil, fmt.Errorf("%s cannot be represented as a LUT", e)
+	}
+	return Make(e.X0, e.Y0, e.X1, e.Y1, steps), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e Easing) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Easing) UnmarshalText(text []byte) error {
+	v, err := ParseEasing(string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// Set implements flag.Value.
+func (e *Easing) Set(s string) error {
+	return e.UnmarshalText([]byte(s))
+}
+
+// ParseError is returned when parsing text fails.
+type ParseError struct {
+	// Line and Column are 1 based. Column is counted in bytes.
+	Line, Column int
+	Msg          string
+}
+
+func (p *ParseError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Msg)
+}
+
+// cssKeywords are the CSS easing keywords, in the order they are looked up
+// by Easing.String.
+var cssKeywords = []struct {
+	name string
+	e    Easing
+}{
+	{"linear", Easing{X0: 0, Y0: 0, X1: 1, Y1: 1}},
+	{"ease", Easing{X0: 0.25, Y0: 0.1, X1: 0.25, Y1: 1}},
+	{"ease-in", Easing{X0: 0.42, Y0: 0, X1: 1, Y1: 1}},
+	{"ease-out", Easing{X0: 0, Y0: 0, X1: 0.58, Y1: 1}},
+	{"ease-in-out", Easing{X0: 0.42, Y0: 0, X1: 0.58, Y1: 1}},
+	{"step-start", Easing{Steps: Steps{1, JumpStart}}},
+	{"step-end", Easing{Steps: Steps{1, JumpEnd}}},
+}
+
+var stepPositions = map[string]StepPosition{
+	"jump-start": JumpStart,
+	"jump-end":   JumpEnd,
+	"jump-none":  JumpNone,
+	"jump-both":  JumpBoth,
+	"start":      JumpStart,
+	"end":        JumpEnd,
+}
+
+// scanner is a minimal tokenizer for CSS-like text.
+type scanner struct {
+	s   string
+	pos int
+}
+
+func (p *scanner) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *scanner) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *scanner) skipSpace() {
+	for !p.eof() && strings.IndexByte(" \t\r\n", p.s[p.pos]) != -1 {
+		p.pos++
+	}
+}
+
+// ident returns the next identifier in lower case, or an empty string.
+func (p *scanner) ident() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.s[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-' || c == '_' || p.pos != start && c >= '0' && c <= '9') {
+			break
+		}
+		p.pos++
+	}
+	return strings.ToLower(p.s[start:p.pos])
+}
+
+// number parses a CSS number.
+func (p *scanner) number() (float64, error) {
+	p.skipSpace()
+	start := p.pos
+	if c := p.peek(); c == '+' || c == '-' {
+		p.pos++
+	}
+	digits := p.digits()
+	if p.peek() == '.' {
+		p.pos++
+		digits += p.digits()
+	}
+	if digits == 0 {
+		p.pos = start
+		return 0, p.errorf(start, "expected a number")
+	}
+	if c := p.peek(); c == 'e' || c == 'E' {
+		p.pos++
+		if c := p.peek(); c == '+' || c == '-' {
+			p.pos++
+		}
+		if p.digits() == 0 {
+			return 0, p.errorf(start, "invalid number %q", p.s[start:p.pos])
+		}
+	}
+	v, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		return 0, p.errorf(start, "invalid number %q", p.s[start:p.pos])
+	}
+	return v, nil
+}
+
+func (p *scanner) digits() int {
+	start := p.pos
+	for !p.eof() && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	return p.pos - start
+}
+
+// expect skips spaces and consumes c.
+func (p *scanner) expect(c byte) error {
+	p.skipSpace()
+	if p.eof() {
+		return p.errorf(p.pos, "expected %q, got end of input", c)
+	}
+	if p.s[p.pos] != c {
+		return p.errorf(p.pos, "expected %q, got %q", c, p.s[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+// errorf returns a *ParseError at the byte offset off.
+func (p *scanner) errorf(off int, format string, args ...interface{}) error {
+	line := 1 + strings.Count(p.s[:off], "\n")
+	col := off + 1
+	if i := strings.LastIndexByte(p.s[:off], '\n'); i != -1 {
+		col = off - i
+	}
+	return &ParseError{Line: line, Column: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// easing parses an <easing-function>.
+func (p *scanner) easing() (Easing, error) {
+	p.skipSpace()
+	start := p.pos
+	name := p.ident()
+	if name == "" {
+		if p.eof() {
+			return Easing{}, p.errorf(start, "expected an easing function, got end of input")
+		}
+		return Easing{}, p.errorf(start, "expected an easing function, got %q", p.s[start])
+	}
+	if p.peek() != '(' {
+		for _, k := range cssKeywords {
+			if k.name == name {
+				return k.e, nil
+			}
+		}
+		return Easing{}, p.errorf(start, "unknown easing keyword %q", name)
+	}
+	p.pos++
+	var e Easing
+	switch name {
+	case "cubic-bezier":
+		var v [4]float64
+		for i := range v {
+			if i != 0 {
+				if err := p.expect(','); err != nil {
+					return Easing{}, err
+				}
+			}
+			p.skipSpace()
+			off := p.pos
+			var err error
+			if v[i], err = p.number(); err != nil {
+				return Easing{}, err
+			}
+			if i%2 == 0 && (v[i] < 0 || v[i] > 1) {
+				return Easing{}, p.errorf(off, "x%d must be in range [0, 1], got %g", i/2, v[i])
+			}
+		}
+		e = Easing{X0: float32(v[0]), Y0: float32(v[1]), X1: float32(v[2]), Y1: float32(v[3])}
+	case "steps":
+		p.skipSpace()
+		off := p.pos
+		n, err := p.number()
+		if err != nil {
+			return Easing{}, err
+		}
+		if n != float64(int(n)) || n < 1 || n > 65535 {
+			return Easing{}, p.errorf(off, "steps count must be an integer in range [1, 65535], got %g", n)
+		}
+		e.Steps.N = uint16(n)
+		p.skipSpace()
+		if p.peek() == ',' {
+			p.pos++
+			p.skipSpace()
+			off := p.pos
+			pos := p.ident()
+			var ok bool
+			if e.Steps.Pos, ok = stepPositions[pos]; !ok {
+				if pos == "" {
+					return Easing{}, p.errorf(off, "expected a step position")
+				}
+				return Easing{}, p.errorf(off, "unknown step position %q", pos)
+			}
+			if e.Steps.Pos == JumpNone && e.Steps.N < 2 {
+				return Easing{}, p.errorf(off, "jump-none requires at least 2 steps")
+			}
+		}
+	default:
+		return Easing{}, p.errorf(start, "unknown easing function %q", name)
+	}
+	if err := p.expect(')'); err != nil {
+		return Easing{}, err
+	}
+	return e, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = Easing{}
+	_ encoding.TextUnmarshaler = &Easing{}
+	_ flag.Value               = &Easing{}
+)
+
+func TestParseEasing(t *testing.T) {
+	data := []struct {
+		in       string
+		expected Easing
+		str      string
+	}{
+		{"linear", Easing{X0: 0, Y0: 0, X1: 1, Y1: 1}, "linear"},
+		{"ease", Easing{X0: 0.25, Y0: 0.1, X1: 0.25, Y1: 1}, "ease"},
+		{"ease-in", Easing{X0: 0.42, Y0: 0, X1: 1, Y1: 1}, "ease-in"},
+		{" Ease-Out ", Easing{X0: 0, Y0: 0, X1: 0.58, Y1: 1}, "ease-out"},
+		{"ease-in-out", Easing{X0: 0.42, Y0: 0, X1: 0.58, Y1: 1}, "ease-in-out"},
+		{"cubic-bezier(0.42, 0, 0.58, 1)", Easing{X0: 0.42, Y0: 0, X1: 0.58, Y1: 1}, "ease-in-out"},
+		{"cubic-bezier(.1,-.5,1,1.5e0)", Easing{X0: 0.1, Y0: -0.5, X1: 1, Y1: 1.5}, "cubic-bezier(0.1, -0.5, 1, 1.5)"},
+		{"cubic-bezier( 0.1 , 0.7 ,\n1.0, 0.1 )", Easing{X0: 0.1, Y0: 0.7, X1: 1, Y1: 0.1}, "cubic-bezier(0.1, 0.7, 1, 0.1)"},
+		{"step-start", Easing{Steps: Steps{1, JumpStart}}, "step-start"},
+		{"step-end", Easing{Steps: Steps{1, JumpEnd}}, "step-end"},
+		{"steps(4)", Easing{Steps: Steps{4, JumpEnd}}, "steps(4)"},
+		{"steps(4, end)", Easing{Steps: Steps{4, JumpEnd}}, "steps(4)"},
+		{"steps(4, start)", Easing{Steps: Steps{4, JumpStart}}, "steps(4, jump-start)"},
+		{"steps(1, jump-start)", Easing{Steps: Steps{1, JumpStart}}, "step-start"},
+		{"steps(2, jump-none)", Easing{Steps: Steps{2, JumpNone}}, "steps(2, jump-none)"},
+		{"steps(3,jump-both)", Easing{Steps: Steps{3, JumpBoth}}, "steps(3, jump-both)"},
+	}
+	for i, line := range data {
+		e, err := ParseEasing(line.in)
+		if err != nil {
+			t.Errorf("#%d: %v", i, err)
+			continue
+		}
+		if e != line.expected {
+			t.Errorf("#%d: %#v != %#v", i, e, line.expected)
+		}
+		if s := e.String(); s != line.str {
+			t.Errorf("#%d: %q != %q", i, s, line.str)
+		}
+		// Round trip.
+		if e2, err := ParseEasing(e.String()); err != nil || e2 != e {
+			t.Errorf("#%d: round trip failed: %#v, %v", i, e2, err)
+		}
+	}
+}
+
+func TestParseEasing_Err(t *testing.T) {
+	data := []struct {
+		in  string
+		err string
+	}{
+		{"", "1:1: expected an easing function, got end of input"},
+		{"  (", "1:3: expected an easing function, got '('"},
+		{"ease-in-outt", "1:1: unknown easing keyword \"ease-in-outt\""},
+		{"ease-in out", "1:9: unexpected \"out\""},
+		{"bezier(1, 2)", "1:1: unknown easing function \"bezier\""},
+		{"cubic-bezier(0.42, 0, 0.58)", "1:27: expected ',', got ')'"},
+		{"cubic-bezier(0.42, 0, 0.58, 1", "1:30: expected ')', got end of input"},
+		{"cubic-bezier(0.42, 0, 1.58, 1)", "1:23: x1 must be in range [0, 1], got 1.58"},
+		{"cubic-bezier(-0.1, 0, 0.58, 1)", "1:14: x0 must be in range [0, 1], got -0.1"},
+		{"cubic-bezier(0.42, a, 0.58, 1)", "1:20: expected a number"},
+		{"cubic-bezier(0.42, 1e, 0.58, 1)", "1:20: invalid number \"1e\""},
+		{"steps(0)", "1:7: steps count must be an integer in range [1, 65535], got 0"},
+		{"steps(1.5)", "1:7: steps count must be an integer in range [1, 65535], got 1.5"},
+		{"steps(4, middle)", "1:10: unknown step position \"middle\""},
+		{"steps(4, )", "1:10: expected a step position"},
+		{"steps(1, jump-none)", "1:10: jump-none requires at least 2 steps"},
+		{"cubic-bezier(0.42, 0,\n  0.58, 1\n  x", "3:3: expected ')', got 'x'"},
+	}
+	for i, line := range data {
+		_, err := ParseEasing(line.in)
+		if err == nil {
+			t.Errorf("#%d: expected error", i)
+			continue
+		}
+		if _, ok := err.(*ParseError); !ok {
+			t.Errorf("#%d: unexpected error type %T", i, err)
+		}
+		if err.Error() != line.err {
+			t.Errorf("#%d: %q != %q", i, err, line.err)
+		}
+	}
+}
+
+func TestEasing_Evaluator(t *testing.T) {
+	e, err := ParseEasing("ease-in-out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := e.LUT(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.String() != Make(0.42, 0, 0.58, 1, 0).String() {
+		t.Fatal(l)
+	}
+	if e.Evaluator(0).Eval(1000) != l.Eval(1000) {
+		t.Fatal("mismatch")
+	}
+	e, err = ParseEasing("steps(2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = e.LUT(0); err == nil || err.Error() != "steps(2) cannot be represented as a LUT" {
+		t.Fatal(err)
+	}
+	if e.Evaluator(0).Eval(40000) != 32768 {
+		t.Fatal("mismatch")
+	}
+}
+
+func TestEasing_Text(t *testing.T) {
+	var v struct {
+		A Easing
+		B Easing
+	}
+	if err := json.Unmarshal([]byte(`{"A": "ease-in", "B": "steps(3, start)"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); s != `{"A":"ease-in","B":"steps(3, jump-start)"}` {
+		t.Fatal(s)
+	}
+	if err := json.Unmarshal([]byte(`{"A": "ease-inn"}`), &v); err == nil {
+		t.Fatal("expected error")
+	}
+
+	var e Easing
+	f := flag.NewFlagSet("test", flag.ContinueOnError)
+	f.Var(&e, "curve", "easing")
+	if err := f.Parse([]string{"-curve=cubic-bezier(0.1, 0.2, 0.3, 0.4)"}); err != nil {
+		t.Fatal(err)
+	}
+	if e != (Easing{X0: 0.1, Y0: 0.2, X1: 0.3, Y1: 0.4}) {
+		t.Fatalf("%#v", e)
+	}
+}
+
+func ExampleParseEasing() {
+	e, err := ParseEasing("cubic-bezier(0, 0, 0.58, 1)")
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("%s\n", e)
+	_, err = ParseEasing("cubic-bezier(0, 0, 1.58, 1)")
+	fmt.Printf("%v\n", err)
+	// Output:
+	// ease-out
+	// 1:20: x1 must be in range [0, 1], got 1.58
+}
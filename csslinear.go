@@ -0,0 +1,311 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CSSLinear returns the LUT as a CSS linear() easing function.
+//
+// It uses as few stops as possible while keeping every point of the LUT
+// within maxError of the resulting piecewise linear function, as read back by
+// ParseLinear. Use 0 to keep all the points that are not collinear.
+//
+// See https://www.w3.org/TR/css-easing-2/#the-linear-easing-function.
+func (l LUT) CSSLinear(maxError uint16) string {
+	n := len(l) - 2
+	prec := linearPrec(n)
+	// stops[i] is point i as a stop read back by ParseLinear. The first and
+	// last inputs are implied.
+	stops := make([]linearStop, n+1)
+	for i := range stops {
+		out, _ := strconv.ParseFloat(formatFloat(float64(l[i])/65535., 5), 64)
+		in, _ := strconv.ParseFloat(formatFloat(float64(i)*100./float64(n), prec), 64)
+		stops[i] = linearStop{in / 100., true, out}
+	}
+	stops[0].in = 0
+	stops[n].in = 1
+
+	// Find the shortest path from the first point to the last one, where each
+	// edge is a segment that fits. count[c] is the number of segments to reach
+	// c and prev[c] the start of the last one.
+	count := make([]int, n+1)
+	prev := make([]int, n+1)
+	for i := range count {
+		count[i] = n + 1
+	}
+	count[0] = 0
+	e := float64(maxError)
+	for a := 0; a < n; a++ {
+		if count[a]+1 >= count[n] {
+			continue
+		}
+		// Range of slopes from stop a that keep the points between a and c
+		// approximately within maxError. It only shrinks as c grows.
+		lo, hi := math.Inf(-1), math.Inf(1)
+		sa := stops[a]
+		for c := a + 1; c <= n; c++ {
+			if k := c - 1; k > a {
+				dx := float64(k)/float64(n) - sa.in
+				if y := float64(l[k]); y > e {
+					lo = math.Max(lo, ((y-e-0.5)/65535.-1e-9-sa.out)/dx)
+				}
+				if y := float64(l[k]); y+e < 65535 {
+					hi = math.Min(hi, ((y+e+0.5)/65535.+1e-9-sa.out)/dx)
+				}
+				if lo > hi {
+					break
+				}
+			}
+			if count[a]+1 >= count[c] {
+				continue
+			}
+			if s := (stops[c].out - sa.out) / (stops[c].in - sa.in); s < lo || s > hi {
+				continue
+			}
+			if l.fits(stops, a, c, maxError) {
+				count[c] = count[a] + 1
+				prev[c] = a
+			}
+		}
+	}
+	path := make([]int, count[n])
+	for i, c := len(path)-1, n; i >= 0; i, c = i-1, prev[c] {
+		path[i] = c
+	}
+
+	b := bytes.NewBufferString("linear(")
+	io.WriteString(b, formatFloat(float64(l[0])/65535., 5))
+	for _, end := range path {
+		io.WriteString(b, ", ")
+		io.WriteString(b, formatFloat(float64(l[end])/65535., 5))
+		if end != n {
+			io.WriteString(b, " ")
+			io.WriteString(b, formatFloat(float64(end)*100./float64(n), prec))
+			io.WriteString(b, "%")
+		}
+	}
+	io.WriteString(b, ")")
+	return b.String()
+}
+
+// linearPrec returns the number of decimals of the input percentages for a
+// LUT of n steps.
+//
+// It is enough so the rounding doesn't move a stop by more than a fraction of
+// a unit, even on the steepest segment.
+func linearPrec(n int) int {
+	prec := 4
+	for f := float64(n) * 65535. * 3; f >= math.Pow10(prec+2); prec++ {
+	}
+	return prec
+}
+
+// fits returns true if all the points evaluated by ParseLinear on the
+// segment between stops a and c are within maxError of the LUT.
+//
+// A point at the same position as a stop is evaluated on the segment which
+// starts at or before it, so the end points are only checked when they are on
+// this segment.
+func (l LUT) fits(stops []linearStop, a, c int, maxError uint16) bool {
+	n := len(l) - 2
+	stepsm1 := 1. / float64(n)
+	sa := stops[a]
+	sc := stops[c]
+	first := a
+	if float64(a)*stepsm1 < sa.in {
+		first++
+	}
+	last := c
+	if c != n && float64(c)*stepsm1 >= sc.in {
+		last--
+	}
+	for k := first; k <= last; k++ {
+		y := float64ToUint16(sa.out + (sc.out-sa.out)*(float64(k)*stepsm1-sa.in)/(sc.in-sa.in))
+		if d := int(y) - int(l[k]); d > int(maxError) || d < -int(maxError) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseLinear parses a CSS linear() easing function into a LUT of `steps`
+// points.
+//
+// Values outside of [0, 1] are clamped.
+//
+// Errors are of type *ParseError.
+func ParseLinear(s string, steps uint16) (LUT, error) {
+	p := scanner{s: s}
+	p.skipSpace()
+	start := p.pos
+	if name := p.ident(); name != "linear" || p.peek() != '(' {
+		return nil, p.errorf(start, "expected linear()")
+	}
+	p.pos++
+	var stops []linearStop
+	for {
+		p.skipSpace()
+		off := p.pos
+		st, err := p.linearStop()
+		if err != nil {
+			return nil, err
+		}
+		// CSS clamps each input to the largest preceding one.
+		for i := range st {
+			if st[i].hasIn {
+				for _, prev := range stops {
+					if prev.hasIn && prev.in > st[i].in {
+						st[i].in = prev.in
+					}
+				}
+			}
+			stops = append(stops, st[i])
+		}
+		p.skipSpace()
+		if p.peek() == ')' {
+			if len(stops) < 2 {
+				return nil, p.errorf(off, "linear() requires at least 2 stops")
+			}
+			p.pos++
+			break
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf(p.pos, "unexpected %q", p.s[p.pos:])
+	}
+
+	// Fill in the missing inputs.
+	if !stops[0].hasIn {
+		stops[0] = linearStop{0, true, stops[0].out}
+	}
+	if last := &stops[len(stops)-1]; !last.hasIn {
+		last.in = 1
+		for _, st := range stops[:len(stops)-1] {
+			if st.hasIn && st.in > last.in {
+				last.in = st.in
+			}
+		}
+		last.hasIn = true
+	}
+	for i := 1; i < len(stops); i++ {
+		if stops[i].hasIn {
+			continue
+		}
+		// Spread the run of missing inputs evenly.
+		j := i
+		for !stops[j].hasIn {
+			j++
+		}
+		a := stops[i-1].in
+		c := stops[j].in
+		for k := i; k < j; k++ {
+			stops[k].in = a + (c-a)*float64(k-i+1)/float64(j-i+1)
+			stops[k].hasIn = true
+		}
+	}
+
+	return MakeFunc(func(x float64) float64 {
+		return evalLinear(stops, x)
+	}, steps), nil
+}
+
+type linearStop struct {
+	in    float64
+	hasIn bool
+	out   float64
+}
+
+// linearStop parses a <linear-stop>: a number and up to two percentages, in
+// any order.
+//
+// Two percentages mean two stops with the same output.
+func (p *scanner) linearStop() ([]linearStop, error) {
+	start := p.pos
+	var out float64
+	hasOut := false
+	var in []float64
+	for {
+		p.skipSpace()
+		if c := p.peek(); c != '+' && c != '-' && c != '.' && (c < '0' || c > '9') {
+			break
+		}
+		off := p.pos
+		v, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() == '%' {
+			p.pos++
+			if len(in) == 2 {
+				return nil, p.errorf(off, "too many percentages")
+			}
+			in = append(in, v/100.)
+			continue
+		}
+		if hasOut {
+			return nil, p.errorf(off, "expected a percentage")
+		}
+		out = v
+		hasOut = true
+	}
+	if !hasOut {
+		if p.eof() {
+			return nil, p.errorf(start, "expected a number, got end of input")
+		}
+		return nil, p.errorf(start, "expected a number")
+	}
+	switch len(in) {
+	case 0:
+		return []linearStop{{out: out}}, nil
+	case 1:
+		return []linearStop{{in[0], true, out}}, nil
+	default:
+		return []linearStop{{in[0], true, out}, {in[1], true, out}}, nil
+	}
+}
+
+// evalLinear evaluates the piecewise linear function defined by the stops,
+// extrapolating past the ends.
+func evalLinear(stops []linearStop, x float64) float64 {
+	// Find the last stop at or before x.
+	i := 0
+	for i < len(stops)-1 && stops[i+1].in <= x {
+		i++
+	}
+	if i == len(stops)-1 {
+		if x == stops[i].in {
+			return stops[i].out
+		}
+		i--
+	}
+	a := stops[i]
+	c := stops[i+1]
+	if c.in == a.in {
+		return c.out
+	}
+	return a.out + (c.out-a.out)*(x-a.in)/(c.in-a.in)
+}
+
+// formatFloat formats v with at most prec decimals, without trailing zeros.
+func formatFloat(v float64, prec int) string {
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if strings.IndexByte(s, '.') != -1 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
@@ -0,0 +1,220 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestLUT_CSSLinear(t *testing.T) {
+	data := []struct {
+		l        LUT
+		maxError uint16
+		expected string
+	}{
+		{LUT{0, 65535, 65535}, 0, "linear(0, 1)"},
+		{LUT{0, 32768, 65535, 65535}, 0, "linear(0, 1)"},
+		{LUT{0, 32767, 65535, 65535}, 0, "linear(0, 0.49999 50%, 1)"},
+		{LUT{0, 32767, 65535, 65535}, 1, "linear(0, 1)"},
+		{LUT{0, 40000, 65535, 65535}, 0, "linear(0, 0.61036 50%, 1)"},
+		{LUT{0, 40000, 65535, 65535}, 7232, "linear(0, 1)"},
+		{LUT{0, 40000, 65535, 65535}, 7231, "linear(0, 0.61036 50%, 1)"},
+		{LUT{65535, 0, 0, 65535, 65535}, 0, "linear(1, 0 33.3333%, 0 66.6667%, 1)"},
+		// Extending the first segment as far as possible would require 5
+		// segments.
+		{LUT{81, 887, 847, 59, 81, 318, 425, 65535}, 100, "linear(0.00124, 0.01353 16.66667%, 0.01292 33.33333%, 0.0009 50%, 0.00649)"},
+	}
+	for i, line := range data {
+		if s := line.l.CSSLinear(line.maxError); s != line.expected {
+			t.Errorf("#%d: %q != %q", i, s, line.expected)
+		}
+	}
+}
+
+func TestLUT_CSSLinear_RoundTrip(t *testing.T) {
+	for _, c := range curves {
+		l := Make(c.x0, c.y0, c.x1, c.y1, 0)
+		for _, maxError := range []uint16{0, 10, 100} {
+			checkCSSLinear(t, l, maxError)
+		}
+		checkCSSLinear(t, Make(c.x0, c.y0, c.x1, c.y1, 256), 16)
+		// Fewer stops for a higher tolerance.
+		if a, b := len(l.CSSLinear(0)), len(l.CSSLinear(100)); a <= b {
+			t.Fatalf("%v: %d <= %d", c, a, b)
+		}
+	}
+}
+
+// checkCSSLinear verifies that the CSSLinear output read back is within
+// maxError of l.
+func checkCSSLinear(t *testing.T, l LUT, maxError uint16) {
+	s := l.CSSLinear(maxError)
+	l2, err := ParseLinear(s, uint16(len(l)-1))
+	if err != nil {
+		t.Fatalf("%q: %v", s, err)
+	}
+	if len(l2) != len(l) {
+		t.Fatalf("%q: %d != %d", s, len(l2), len(l))
+	}
+	for i := range l {
+		if d := int(l2[i]) - int(l[i]); d > int(maxError) || d < -int(maxError) {
+			t.Fatalf("%q: %s != %s", s, l2, l)
+		}
+	}
+}
+
+func TestLUT_CSSLinear_Optimal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		l := make(LUT, 3+r.Intn(8), 12)
+		for j := range l {
+			l[j] = uint16(r.Intn(1000))
+		}
+		l = append(l, 65535)
+		maxError := uint16(r.Intn(200))
+		checkCSSLinear(t, l, maxError)
+		// Compare with an exhaustive search.
+		s := l.CSSLinear(maxError)
+		if got, want := strings.Count(s, ","), minSegments(l, maxError); got != want {
+			t.Fatalf("%v, %d: %q: %d segments, expected %d", l, maxError, s, got, want)
+		}
+	}
+}
+
+func TestLUT_CSSLinear_Steep(t *testing.T) {
+	// Jumps over a single step require precise inputs.
+	l := make(LUT, 4097, 4098)
+	for i := 2048; i < len(l); i++ {
+		l[i] = 65535
+	}
+	l = append(l, 65535)
+	s := l.CSSLinear(0)
+	if s != "linear(0, 0 49.9755859%, 1 50%, 1)" {
+		t.Fatal(s)
+	}
+	l2, err := ParseLinear(s, uint16(len(l)-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l2.String() != l.String() {
+		t.Fatalf("%q: %s", s, l2)
+	}
+}
+
+// minSegments returns the minimum number of segments for CSSLinear by trying
+// all the combinations of stops.
+func minSegments(l LUT, maxError uint16) int {
+	n := len(l) - 2
+	best := n
+	for mask := 0; mask < 1<<uint(n-1); mask++ {
+		// Point i+1 is a stop if bit i is set.
+		path := []int{0}
+		for i := 0; i < n-1; i++ {
+			if mask&(1<<uint(i)) != 0 {
+				path = append(path, i+1)
+			}
+		}
+		path = append(path, n)
+		if len(path)-1 >= best {
+			continue
+		}
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "linear(%s", formatFloat(float64(l[0])/65535., 5))
+		for _, end := range path[1:] {
+			fmt.Fprintf(&b, ", %s", formatFloat(float64(l[end])/65535., 5))
+			if end != n {
+				fmt.Fprintf(&b, " %s%%", formatFloat(float64(end)*100./float64(n), linearPrec(n)))
+			}
+		}
+		b.WriteString(")")
+		l2, err := ParseLinear(b.String(), uint16(n+1))
+		if err != nil {
+			panic(err)
+		}
+		ok := true
+		for j := range l {
+			if d := int(l2[j]) - int(l[j]); d > int(maxError) || d < -int(maxError) {
+				ok = false
+			}
+		}
+		if ok {
+			best = len(path) - 1
+		}
+	}
+	return best
+}
+
+func TestParseLinear(t *testing.T) {
+	data := []struct {
+		in       string
+		expected string
+	}{
+		{"linear(0, 1)", "LUT{(0, 0), (16383, 16384), (32767, 32768), (49151, 49151), (65535, 65535)}"},
+		{"linear(1, 0)", "LUT{(0, 65535), (16383, 49151), (32767, 32768), (49151, 16384), (65535, 0)}"},
+		// Missing inputs are spread evenly.
+		{"linear(0, 0.5, 0.5, 1)", "LUT{(0, 0), (16383, 24576), (32767, 32768), (49151, 40959), (65535, 65535)}"},
+		// Two percentages is a hold.
+		{"linear(0, 0.5 25% 75%, 1)", "LUT{(0, 0), (16383, 32768), (32767, 32768), (49151, 32768), (65535, 65535)}"},
+		{"linear(0, 25% 0.5 75%, 1)", "LUT{(0, 0), (16383, 32768), (32767, 32768), (49151, 32768), (65535, 65535)}"},
+		// Inputs going backward are clamped, making a jump.
+		{"linear(0, 0.25 50%, 0.75 25%, 1)", "LUT{(0, 0), (16383, 8192), (32767, 49151), (49151, 57343), (65535, 65535)}"},
+		// Out of range values are clamped.
+		{"linear(-1, 2)", "LUT{(0, 0), (16383, 0), (32767, 32768), (49151, 65535), (65535, 65535)}"},
+		// Extrapolation.
+		{"linear(0.5 50%, 1)", "LUT{(0, 0), (16383, 16384), (32767, 32768), (49151, 49151), (65535, 65535)}"},
+		{"  LINEAR( 0 ,1 0% ) ", "LUT{(0, 65535), (16383, 65535), (32767, 65535), (49151, 65535), (65535, 65535)}"},
+	}
+	for i, line := range data {
+		l, err := ParseLinear(line.in, 5)
+		if err != nil {
+			t.Errorf("#%d: %v", i, err)
+			continue
+		}
+		if s := l.String(); s != line.expected {
+			t.Errorf("#%d: %s != %s", i, s, line.expected)
+		}
+	}
+}
+
+func TestParseLinear_Err(t *testing.T) {
+	data := []struct {
+		in  string
+		err string
+	}{
+		{"", "1:1: expected linear()"},
+		{"linear", "1:1: expected linear()"},
+		{"ease(0, 1)", "1:1: expected linear()"},
+		{"linear()", "1:8: expected a number"},
+		{"linear(0)", "1:8: linear() requires at least 2 stops"},
+		{"linear(0, 1", "1:12: expected ',', got end of input"},
+		{"linear(0, 1 2)", "1:13: expected a percentage"},
+		{"linear(0, 1 2% 3% 4%)", "1:19: too many percentages"},
+		{"linear(0, 50%)", "1:11: expected a number"},
+		{"linear(0, a)", "1:11: expected a number"},
+		{"linear(0, 1) x", "1:14: unexpected \"x\""},
+		{"linear(0,\n  1 x)", "2:5: expected ',', got 'x'"},
+	}
+	for i, line := range data {
+		_, err := ParseLinear(line.in, 0)
+		if err == nil {
+			t.Errorf("#%d: expected error", i)
+			continue
+		}
+		if err.Error() != line.err {
+			t.Errorf("#%d: %q != %q", i, err, line.err)
+		}
+	}
+}
+
+func ExampleLUT_CSSLinear() {
+	l := Make(0.42, 0, 0.58, 1, 0)
+	fmt.Printf("%s\n", l.CSSLinear(200))
+	// Output:
+	// linear(0, 0.00809 6.45161%, 0.0332 12.90323%, 0.07634 19.35484%, 0.13785 25.80645%, 0.217 32.25806%, 0.31148 38.70968%, 0.68852 61.29032%, 0.783 67.74194%, 0.86215 74.19355%, 0.92366 80.64516%, 0.9668 87.09677%, 0.99191 93.54839%, 1)
+}
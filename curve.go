@@ -0,0 +1,115 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/maruel/fastbezier/internal"
+)
+
+// Curve is the cubic bezier curve (0, 0), (X0, Y0), (X1, Y1), (1, 1).
+//
+// Unlike a LUT, it keeps the parameters that define the curve so it can be
+// regenerated at any resolution.
+//
+// It implements encoding.TextMarshaler, encoding.TextUnmarshaler,
+// json.Marshaler and json.Unmarshaler so it can be used directly in
+// configuration files.
+type Curve struct {
+	X0, Y0, X1, Y1 float32
+}
+
+// LUT returns a LUT of `steps` points generated with Make.
+func (c Curve) LUT(steps uint16) LUT {
+	return Make(c.X0, c.Y0, c.X1, c.Y1, steps)
+}
+
+// Fast returns a LUT of `steps` points generated with MakeFast.
+func (c Curve) Fast(steps uint16) LUT {
+	return MakeFast(c.X0, c.Y0, c.X1, c.Y1, steps)
+}
+
+// Precise returns an evaluator that calculates the curve for each value.
+//
+// It is orders of magnitude slower than a LUT and is meant to be used as a
+// reference.
+func (c Curve) Precise() Evaluator {
+	return precise(c)
+}
+
+// String returns the CSS representation, using a keyword when possible.
+func (c Curve) String() string {
+	return c.easing().String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Curve) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// It accepts the CSS cubic bezier keywords and cubic-bezier().
+func (c *Curve) UnmarshalText(text []byte) error {
+	e, err := ParseEasing(string(text))
+	if err != nil {
+		return err
+	}
+	if e.Steps.N != 0 {
+		return fmt.Errorf("%s is not a cubic bezier curve", e)
+	}
+	*c = Curve{e.X0, e.Y0, e.X1, e.Y1}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// The curve is encoded as a string in its CSS representation.
+func (c Curve) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It accepts either a string as accepted by UnmarshalText or an array of 4
+// numbers [x0, y0, x1, y1].
+func (c *Curve) UnmarshalJSON(b []byte) error {
+	if b = bytes.TrimSpace(b); len(b) != 0 && b[0] == '[' {
+		var v []float32
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		if len(v) != 4 {
+			return fmt.Errorf("expected 4 values, got %d", len(v))
+		}
+		for i := 0; i < 4; i += 2 {
+			if v[i] < 0 || v[i] > 1 {
+				return fmt.Errorf("x%d must be in range [0, 1], got %g", i/2, v[i])
+			}
+		}
+		*c = Curve{v[0], v[1], v[2], v[3]}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+func (c Curve) easing() Easing {
+	return Easing{X0: c.X0, Y0: c.Y0, X1: c.X1, Y1: c.Y1}
+}
+
+// precise is the precise evaluation of a cubic bezier curve in the uint16
+// domain.
+type precise Curve
+
+func (p precise) Eval(x uint16) uint16 {
+	return internal.CubicBezier16(p.X0, p.Y0, p.X1, p.Y1, x)
+}
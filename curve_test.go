@@ -0,0 +1,128 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/maruel/fastbezier/internal"
+)
+
+var (
+	_ encoding.TextMarshaler   = Curve{}
+	_ encoding.TextUnmarshaler = &Curve{}
+	_ json.Marshaler           = Curve{}
+	_ json.Unmarshaler         = &Curve{}
+)
+
+func TestCurve(t *testing.T) {
+	for _, c := range curves {
+		v := Curve{c.x0, c.y0, c.x1, c.y1}
+		if l := v.LUT(0); !reflect.DeepEqual(l, Make(c.x0, c.y0, c.x1, c.y1, 0)) {
+			t.Fatalf("%s: %v", v, l)
+		}
+		if l := v.Fast(0); !reflect.DeepEqual(l, MakeFast(c.x0, c.y0, c.x1, c.y1, 0)) {
+			t.Fatalf("%s: %v", v, l)
+		}
+		p := v.Precise()
+		for x := 0; x < 65536; x += 1000 {
+			if y, expected := p.Eval(uint16(x)), internal.CubicBezier16(c.x0, c.y0, c.x1, c.y1, uint16(x)); y != expected {
+				t.Fatalf("%s: x=%d %d != %d", v, x, y, expected)
+			}
+		}
+	}
+}
+
+func TestCurve_String(t *testing.T) {
+	data := []struct {
+		c        Curve
+		expected string
+	}{
+		{Curve{0, 0, 1, 1}, "linear"},
+		{Curve{0.42, 0, 0.58, 1}, "ease-in-out"},
+		{Curve{0.1, -0.5, 1, 1.5}, "cubic-bezier(0.1, -0.5, 1, 1.5)"},
+	}
+	for i, line := range data {
+		if s := line.c.String(); s != line.expected {
+			t.Errorf("#%d: %q != %q", i, s, line.expected)
+		}
+		var c Curve
+		if err := c.UnmarshalText([]byte(line.expected)); err != nil || c != line.c {
+			t.Errorf("#%d: %v %v", i, c, err)
+		}
+	}
+}
+
+func TestCurve_UnmarshalText_Err(t *testing.T) {
+	data := []struct {
+		in  string
+		err string
+	}{
+		{"steps(4)", "steps(4) is not a cubic bezier curve"},
+		{"ease-foo", "1:1: unknown easing keyword \"ease-foo\""},
+	}
+	for i, line := range data {
+		var c Curve
+		if err := c.UnmarshalText([]byte(line.in)); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestCurve_JSON(t *testing.T) {
+	type config struct {
+		Fade  Curve
+		Slide Curve
+	}
+	b, err := json.Marshal(config{Curve{0.25, 0.1, 0.25, 1}, Curve{0.5, 0, 0.5, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); s != `{"Fade":"ease","Slide":"cubic-bezier(0.5, 0, 0.5, 1)"}` {
+		t.Fatal(s)
+	}
+	var c config
+	if err := json.Unmarshal([]byte(`{"Fade": "ease-in", "Slide": [0.1, -0.2, 0.3, 1.4]}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (config{Curve{0.42, 0, 1, 1}, Curve{0.1, -0.2, 0.3, 1.4}}); c != expected {
+		t.Fatalf("%v != %v", c, expected)
+	}
+}
+
+func TestCurve_UnmarshalJSON_Err(t *testing.T) {
+	data := []struct {
+		in  string
+		err string
+	}{
+		{`[0, 0, 1]`, "expected 4 values, got 3"},
+		{`[0, 0, 1.5, 1]`, "x1 must be in range [0, 1], got 1.5"},
+		{`"steps(2)"`, "steps(2) is not a cubic bezier curve"},
+		{`1`, "json: cannot unmarshal number into Go value of type string"},
+	}
+	for i, line := range data {
+		var c Curve
+		if err := json.Unmarshal([]byte(line.in), &c); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func ExampleCurve() {
+	var c Curve
+	if err := json.Unmarshal([]byte(`"cubic-bezier(0.42, 0, 0.58, 1)"`), &c); err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("%s\n", c)
+	fmt.Printf("%s\n", c.LUT(5))
+	// Output:
+	// ease-in-out
+	// LUT{(0, 0), (16383, 8465), (32767, 32768), (49151, 57070), (65535, 65535)}
+}
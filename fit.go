@@ -0,0 +1,198 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/maruel/fastbezier/internal"
+)
+
+// Norm is the error measure minimized by Fit.
+type Norm int
+
+const (
+	// L2 minimizes the root mean square error.
+	L2 Norm = iota
+	// MaxNorm minimizes the maximum absolute error.
+	MaxNorm
+)
+
+func (n Norm) String() string {
+	switch n {
+	case L2:
+		return "L2"
+	case MaxNorm:
+		return "MaxNorm"
+	default:
+		return fmt.Sprintf("Norm(%d)", int(n))
+	}
+}
+
+// FitResult is the cubic bezier curve found by Fit.
+type FitResult struct {
+	X0, Y0, X1, Y1 float32
+	// Error is the residual error in the uint16 domain; the root mean square
+	// error for L2 and the maximum absolute error for MaxNorm.
+	Error float64
+}
+
+// Fit returns the cubic bezier curve (0, 0), (x0, y0), (x1, y1), (1, 1) that
+// best matches the samples.
+//
+// It uses the Nelder-Mead method from multiple starting points, so the
+// result is a local optimum that is generally but not necessarily global.
+// The run time is proportional to the number of samples, a few dozens is
+// generally enough.
+func Fit(samples []Point, n Norm) (FitResult, error) {
+	if len(samples) == 0 {
+		return FitResult{}, errors.New("at least 1 sample is required")
+	}
+	if n != L2 && n != MaxNorm {
+		return FitResult{}, fmt.Errorf("unknown norm %s", n)
+	}
+	x := make([]float32, len(samples))
+	y := make([]float64, len(samples))
+	for i, s := range samples {
+		x[i] = float32(s.X) / 65535.
+		y[i] = float64(s.Y)
+	}
+	cost := func(p [4]float64) float64 {
+		p[0] = clamp01(p[0])
+		p[2] = clamp01(p[2])
+		sum := 0.
+		for i := range x {
+			d := math.Abs(float64(internal.CubicBezier(float32(p[0]), float32(p[1]), float32(p[2]), float32(p[3]), x[i]))*65535. - y[i])
+			if n == L2 {
+				sum += d * d
+			} else if d > sum {
+				sum = d
+			}
+		}
+		if n == L2 {
+			return math.Sqrt(sum / float64(len(x)))
+		}
+		return sum
+	}
+
+	starts := fitStarts
+	if n == MaxNorm {
+		// The maximum error is not smooth, which makes Nelder-Mead get stuck
+		// easily. Start from the L2 fit which is generally close.
+		r, _ := Fit(samples, L2)
+		starts = append([][4]float64{{float64(r.X0), float64(r.Y0), float64(r.X1), float64(r.Y1)}}, starts...)
+	}
+	best := [4]float64{}
+	bestCost := math.Inf(1)
+	for _, start := range starts {
+		p, c := nelderMead(cost, start)
+		// Restart once from the result to escape a collapsed simplex.
+		if p, c = nelderMead(cost, p); c < bestCost {
+			best = p
+			bestCost = c
+		}
+	}
+	return FitResult{
+		X0:    float32(clamp01(best[0])),
+		Y0:    float32(best[1]),
+		X1:    float32(clamp01(best[2])),
+		Y1:    float32(best[3]),
+		Error: bestCost,
+	}, nil
+}
+
+// FitLUT is the same as Fit using the points of a LUT as samples.
+func FitLUT(l LUT, n Norm) (FitResult, error) {
+	steps := len(l) - 2
+	if steps < 1 {
+		return FitResult{}, errors.New("invalid LUT")
+	}
+	samples := make([]Point, steps+1)
+	for i := range samples {
+		samples[i] = Point{uint16(i * 65535 / steps), l[i]}
+	}
+	return Fit(samples, n)
+}
+
+// fitStarts are the starting points for Fit: linear and the CSS keywords.
+var fitStarts = [][4]float64{
+	{1. / 3., 1. / 3., 2. / 3., 2. / 3.},
+	{0.25, 0.1, 0.25, 1},
+	{0.42, 0, 1, 1},
+	{0, 0, 0.58, 1},
+	{0.42, 0, 0.58, 1},
+}
+
+// nelderMead minimizes f starting at p.
+func nelderMead(f func([4]float64) float64, p [4]float64) ([4]float64, float64) {
+	const (
+		maxIter   = 2000
+		tolerance = 1e-9
+		step      = 0.1
+	)
+	type vertex struct {
+		p [4]float64
+		c float64
+	}
+	var s [5]vertex
+	s[0] = vertex{p, f(p)}
+	for i := 0; i < 4; i++ {
+		q := p
+		q[i] += step
+		s[i+1] = vertex{q, f(q)}
+	}
+	for iter := 0; iter < maxIter; iter++ {
+		sort.Slice(s[:], func(i, j int) bool { return s[i].c < s[j].c })
+		if s[4].c-s[0].c <= tolerance*(1+math.Abs(s[0].c)) {
+			break
+		}
+		// Centroid of all but the worst.
+		var c [4]float64
+		for _, v := range s[:4] {
+			for i := range c {
+				c[i] += v.p[i] / 4
+			}
+		}
+		along := func(t float64) vertex {
+			var q [4]float64
+			for i := range q {
+				q[i] = c[i] + t*(s[4].p[i]-c[i])
+			}
+			return vertex{q, f(q)}
+		}
+		r := along(-1)
+		switch {
+		case r.c < s[0].c:
+			if e := along(-2); e.c < r.c {
+				s[4] = e
+			} else {
+				s[4] = r
+			}
+		case r.c < s[3].c:
+			s[4] = r
+		default:
+			t := 0.5
+			if r.c < s[4].c {
+				t = -0.5
+			}
+			if k := along(t); k.c < math.Min(r.c, s[4].c) {
+				s[4] = k
+				continue
+			}
+			// Shrink toward the best.
+			for j := 1; j < 5; j++ {
+				for i := range s[j].p {
+					s[j].p[i] = s[0].p[i] + 0.5*(s[j].p[i]-s[0].p[i])
+				}
+				s[j].c = f(s[j].p)
+			}
+		}
+	}
+	sort.Slice(s[:], func(i, j int) bool { return s[i].c < s[j].c })
+	return s[0].p, s[0].c
+}
@@ -0,0 +1,105 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maruel/fastbezier/internal"
+)
+
+func TestFit(t *testing.T) {
+	for _, c := range curves {
+		samples := make([]Point, 33)
+		for i := range samples {
+			x := uint16(i * 65535 / 32)
+			samples[i] = Point{x, internal.CubicBezier16(c.x0, c.y0, c.x1, c.y1, x)}
+		}
+		for _, n := range []Norm{L2, MaxNorm} {
+			r, err := Fit(samples, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Error > 2 {
+				t.Errorf("%v %s: residual %g; %+v", c, n, r.Error, r)
+			}
+			// Verify the curve, not the parameters, since multiple parameters can
+			// give nearly the same curve.
+			for x := 0; x < 65536; x += 256 {
+				a := internal.CubicBezier16(c.x0, c.y0, c.x1, c.y1, uint16(x))
+				b := internal.CubicBezier16(r.X0, r.Y0, r.X1, r.Y1, uint16(x))
+				if d := int(a) - int(b); d > 20 || d < -20 {
+					t.Errorf("%v %s: x=%d %d != %d; %+v", c, n, x, a, b, r)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestFit_Noisy(t *testing.T) {
+	// Perceived brightness, roughly gamma 2.2, which is not a bezier.
+	samples := make([]Point, 17)
+	for i := range samples {
+		x := float64(i) / 16
+		samples[i] = Point{uint16(x * 65535), uint16(math.Pow(x, 2.2)*65535 + 0.5)}
+	}
+	l2, err := Fit(samples, L2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	max, err := Fit(samples, MaxNorm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l2.Error > 300 || max.Error > 600 {
+		t.Fatalf("%+v %+v", l2, max)
+	}
+	// Compute the max error of the L2 fit; it must not be better than the max
+	// norm fit.
+	worst := 0.
+	for _, s := range samples {
+		d := math.Abs(float64(internal.CubicBezier16(l2.X0, l2.Y0, l2.X1, l2.Y1, s.X)) - float64(s.Y))
+		worst = math.Max(worst, d)
+	}
+	if worst+1 < max.Error {
+		t.Fatalf("max norm fit %g is worse than L2 fit %g", max.Error, worst)
+	}
+}
+
+func TestFitLUT(t *testing.T) {
+	r, err := FitLUT(Make(0.42, 0, 0.58, 1, 0), MaxNorm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Error > 2 {
+		t.Fatalf("%+v", r)
+	}
+}
+
+func TestFit_Err(t *testing.T) {
+	if _, err := Fit(nil, L2); err == nil || err.Error() != "at least 1 sample is required" {
+		t.Fatal(err)
+	}
+	if _, err := Fit([]Point{{0, 0}}, Norm(3)); err == nil || err.Error() != "unknown norm Norm(3)" {
+		t.Fatal(err)
+	}
+	if _, err := FitLUT(LUT{0}, L2); err == nil || err.Error() != "invalid LUT" {
+		t.Fatal(err)
+	}
+}
+
+func ExampleFitLUT() {
+	r, err := FitLUT(Make(0, 0, 0.58, 1, 0), L2)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("x1=%.2f y1=%.2f error=%.1f\n", r.X1, r.Y1, r.Error)
+	// Output:
+	// x1=0.58 y1=1.00 error=0.2
+}
@@ -0,0 +1,105 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// generatorVersion is recorded in the header of generated files. Increase it
+// whenever the generated code changes.
+const generatorVersion = 1
+
+// Table is a named LUT to be emitted as source code.
+type Table struct {
+	// Name is the identifier of the table in the generated code. It must be a
+	// valid ASCII identifier.
+	Name string
+	// Desc describes how the LUT was generated. It is written as a comment.
+	Desc string
+	LUT  LUT
+}
+
+// NewTable returns a Table for the curve c.
+//
+// The LUT is generated with MakeFast if fast is true, Make otherwise. Desc
+// records the parameters so the table can be regenerated.
+func NewTable(name string, c Curve, steps uint16, fast bool) Table {
+	var l LUT
+	f := "Make"
+	if fast {
+		l = c.Fast(steps)
+		f = "MakeFast"
+	} else {
+		l = c.LUT(steps)
+	}
+	return Table{
+		Name: name,
+		Desc: fmt.Sprintf("cubic-bezier(%g, %g, %g, %g), %d steps, %s", c.X0, c.Y0, c.X1, c.Y1, len(l)-1, f),
+		LUT:  l,
+	}
+}
+
+// Validate returns an error if the table cannot be emitted by the
+// generators; the name must be an ASCII identifier and the LUT must be valid.
+//
+// Some generators further restrict the name, e.g. to exclude keywords.
+func (t Table) Validate() error {
+	if !isIdent(t.Name) {
+		return fmt.Errorf("invalid name %q", t.Name)
+	}
+	if len(t.LUT) < 3 || t.LUT[len(t.LUT)-1] != 65535 {
+		return errors.New("invalid LUT")
+	}
+	return nil
+}
+
+// validateTables verifies that the tables can be emitted.
+func validateTables(tables []Table) error {
+	if len(tables) == 0 {
+		return errors.New("at least one table is required")
+	}
+	names := map[string]bool{}
+	for i, t := range tables {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("table %d: %v", i, err)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("table %d: duplicate name %q", i, t.Name)
+		}
+		names[t.Name] = true
+	}
+	return nil
+}
+
+// isIdent returns true if s is an ASCII identifier, which is valid in all the
+// generated languages.
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || i != 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// writeValues writes comma separated values, 8 per line, each line prefixed
+// with indent.
+func writeValues(w io.Writer, values []uint16, indent string) {
+	for i, y := range values {
+		if i%8 == 0 {
+			fmt.Fprintf(w, "\n%s", indent)
+		} else {
+			io.WriteString(w, " ")
+		}
+		fmt.Fprintf(w, "%d,", y)
+	}
+}
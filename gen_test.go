@@ -0,0 +1,63 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"reflect"
+	"testing"
+)
+
+// genTables are the tables used to verify the generated code; a Make table,
+// a MakeFast table and one that isn't monotonic.
+var genTables = []Table{
+	NewTable("EaseIn", Curve{0.42, 0, 1, 1}, 0, false),
+	NewTable("EaseOut", Curve{0, 0, 0.58, 1}, 9, true),
+	{Name: "Custom", LUT: LUT{65535, 0, 65535, 65535}},
+}
+
+func TestNewTable(t *testing.T) {
+	c := Curve{0.42, 0, 0.58, 1}
+	tbl := NewTable("EaseInOut", c, 0, false)
+	if tbl.Desc != "cubic-bezier(0.42, 0, 0.58, 1), 32 steps, Make" {
+		t.Fatal(tbl.Desc)
+	}
+	if !reflect.DeepEqual(tbl.LUT, c.LUT(0)) {
+		t.Fatal(tbl.LUT)
+	}
+	tbl = NewTable("EaseInOut", c, 16, true)
+	if tbl.Desc != "cubic-bezier(0.42, 0, 0.58, 1), 16 steps, MakeFast" {
+		t.Fatal(tbl.Desc)
+	}
+	if !reflect.DeepEqual(tbl.LUT, c.Fast(16)) {
+		t.Fatal(tbl.LUT)
+	}
+}
+
+func TestValidateTables(t *testing.T) {
+	l := Make(0, 0, 1, 1, 3)
+	data := []struct {
+		tables []Table
+		err    string
+	}{
+		{nil, "at least one table is required"},
+		{[]Table{{Name: "", LUT: l}}, "table 0: invalid name \"\""},
+		{[]Table{{Name: "a", LUT: l}, {Name: "1a", LUT: l}}, "table 1: invalid name \"1a\""},
+		{[]Table{{Name: "a-b", LUT: l}}, "table 0: invalid name \"a-b\""},
+		{[]Table{{Name: "a", LUT: l}, {Name: "a", LUT: l}}, "table 1: duplicate name \"a\""},
+		{[]Table{{Name: "a", LUT: LUT{0, 65535}}}, "table 0: invalid LUT"},
+		{[]Table{{Name: "a", LUT: LUT{0, 65535, 0}}}, "table 0: invalid LUT"},
+	}
+	for i, line := range data {
+		if err := validateTables(line.tables); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+	if err := validateTables([]Table{{Name: "_a1", LUT: l}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := (Table{Name: "a"}).Validate(); err == nil || err.Error() != "invalid LUT" {
+		t.Fatal(err)
+	}
+}
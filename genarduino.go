@@ -0,0 +1,96 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// arduinoEval is the Arduino implementation of LUT.Eval reading the table
+// from PROGMEM. It must return the exact same values.
+const arduinoEval = `#ifndef FASTBEZIER_EVAL_P
+#define FASTBEZIER_EVAL_P(lut, x) fastbezier_eval_P((lut), sizeof(lut) / sizeof((lut)[0]), (x))
+
+// fastbezier_eval_P evaluates a table stored in PROGMEM of len items,
+// including the trailing 65535.
+static inline uint16_t fastbezier_eval_P(const uint16_t *lut, size_t len, uint16_t x) {
+  const uint32_t steps = (uint32_t)(len - 2);
+  const uint32_t x32 = x;
+  const uint32_t index = x32 * steps / 65535;
+  const uint32_t next_x = (index + 1) * 65535 / steps;
+  const uint32_t base_x = index * 65535 / steps;
+  const uint32_t a = (uint32_t)pgm_read_word(lut + index) * (next_x - x32);
+  const uint32_t b = (uint32_t)pgm_read_word(lut + index + 1) * (x32 - base_x);
+  return (uint16_t)((a + b) / (next_x - base_x));
+}
+#endif
+`
+
+// WriteArduino writes an Arduino library in the directory dir, which is
+// created if needed. The library is named after the last element of dir,
+// which must be a valid identifier.
+//
+// The library contains library.properties, also understood by PlatformIO,
+// and src/<name>.h which declares each table in PROGMEM. The header also
+// defines fastbezier_eval_P() and the FASTBEZIER_EVAL_P(lut, x) macro which
+// read the table with pgm_read_word() and return the same values as
+// LUT.Eval.
+func WriteArduino(dir string, tables ...Table) error {
+	name := filepath.Base(dir)
+	if !isIdent(name) {
+		return fmt.Errorf("invalid library name %q", name)
+	}
+	if err := validateTables(tables); err != nil {
+		return err
+	}
+	var props, hdr bytes.Buffer
+	writeArduinoProperties(&props, name)
+	if err := writeArduinoHeader(&hdr, tables); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "library.properties"), props.Bytes(), 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "src", name+".h"), hdr.Bytes(), 0644)
+}
+
+func writeArduinoProperties(w io.Writer, name string) {
+	fmt.Fprintf(w, `name=%s
+version=1.0.0
+author=fastbezier generator v%d
+maintainer=fastbezier generator v%d
+sentence=Precalculated easing curves.
+paragraph=Generated by fastbezier. Tables are stored in PROGMEM.
+category=Other
+url=https://github.com/maruel/fastbezier
+architectures=*
+includes=%s.h
+`, name, generatorVersion, generatorVersion, name)
+}
+
+func writeArduinoHeader(w io.Writer, tables []Table) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "// Code generated by fastbezier generator v%d; DO NOT EDIT.\n\n#pragma once\n\n#include <Arduino.h>\n#include <stddef.h>\n#include <stdint.h>\n\n", generatorVersion)
+	io.WriteString(b, arduinoEval)
+	for _, t := range tables {
+		io.WriteString(b, "\n")
+		if t.Desc != "" {
+			fmt.Fprintf(b, "// %s is %s.\n", t.Name, t.Desc)
+		}
+		fmt.Fprintf(b, "static const uint16_t %s[%d] PROGMEM = {", t.Name, len(t.LUT))
+		writeValues(b, t.LUT, "  ")
+		io.WriteString(b, "\n};\n")
+	}
+	return b.Flush()
+}
@@ -0,0 +1,65 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteArduino(t *testing.T) {
+	d, err := ioutil.TempDir("", "fastbezier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	tables := genTables
+	lib := filepath.Join(d, "Easing")
+	if err := WriteArduino(lib, tables...); err != nil {
+		t.Fatal(err)
+	}
+	props, err := ioutil.ReadFile(filepath.Join(lib, "library.properties"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(props), "name=Easing\nversion=1.0.0\n") || !strings.HasSuffix(string(props), "includes=Easing.h\n") {
+		t.Fatal(string(props))
+	}
+	hdr, err := ioutil.ReadFile(filepath.Join(lib, "src", "Easing.h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(hdr), "// EaseOut is cubic-bezier(0, 0, 0.58, 1), 9 steps, MakeFast.\nstatic const uint16_t EaseOut[10] PROGMEM = {\n") {
+		t.Fatal(string(hdr))
+	}
+
+	// Compile with a stub Arduino.h to verify the values.
+	arduino := `#define PROGMEM
+#define pgm_read_word(addr) (*(const uint16_t *)(addr))
+`
+	main := `#include <stdio.h>
+#include "tables.h"
+
+int main(void) {
+  for (uint32_t x = 0; x < 65536; x += 7) {
+    printf("%u %u %u\n", FASTBEZIER_EVAL_P(EaseIn, x), FASTBEZIER_EVAL_P(EaseOut, x), FASTBEZIER_EVAL_P(Custom, x));
+  }
+  return 0;
+}
+`
+	checkEvalOutput(t, runC(t, map[string]string{"Arduino.h": arduino, "tables.h": string(hdr), "main.c": main}), tables)
+}
+
+func TestWriteArduino_Err(t *testing.T) {
+	if err := WriteArduino(filepath.Join("foo", "my-lib"), Table{Name: "a", LUT: LUT{0, 32767, 65535, 65535}}); err == nil || err.Error() != "invalid library name \"my-lib\"" {
+		t.Fatal(err)
+	}
+	if err := WriteArduino("lib"); err == nil || err.Error() != "at least one table is required" {
+		t.Fatal(err)
+	}
+}
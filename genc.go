@@ -0,0 +1,157 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// cEval is the C implementation of LUT.Eval. It must return the exact same
+// values.
+const cEval = `#ifndef FASTBEZIER_EVAL
+#define FASTBEZIER_EVAL(lut, x) fastbezier_eval((lut), sizeof(lut) / sizeof((lut)[0]), (x))
+
+// fastbezier_eval evaluates a table of len items, including the trailing
+// 65535.
+static inline uint16_t fastbezier_eval(const uint16_t *lut, size_t len, uint16_t x) {
+  const uint32_t steps = (uint32_t)(len - 2);
+  const uint32_t x32 = x;
+  const uint32_t index = x32 * steps / 65535;
+  const uint32_t next_x = (index + 1) * 65535 / steps;
+  const uint32_t base_x = index * 65535 / steps;
+  const uint32_t a = (uint32_t)lut[index] * (next_x - x32);
+  const uint32_t b = (uint32_t)lut[index + 1] * (x32 - base_x);
+  return (uint16_t)((a + b) / (next_x - base_x));
+}
+#endif
+`
+
+// cBank is the C reader for a bank generated by MarshalBank. It reads the
+// buffer one byte at a time so it doesn't depend on the alignment nor the
+// endianness.
+const cBank = `#ifndef FASTBEZIER_BANK
+#define FASTBEZIER_BANK
+
+static inline uint16_t fastbezier_read16(const uint8_t *p) {
+  return (uint16_t)(p[0] | ((uint16_t)p[1] << 8));
+}
+
+static inline uint32_t fastbezier_read32(const uint8_t *p) {
+  return (uint32_t)p[0] | ((uint32_t)p[1] << 8) | ((uint32_t)p[2] << 16) | ((uint32_t)p[3] << 24);
+}
+
+// fastbezier_bank_valid returns 1 if the bank of size bytes has a valid
+// header, CRC and index, 0 otherwise.
+static inline int fastbezier_bank_valid(const uint8_t *bank, size_t size) {
+  if (size < 12 || bank[0] != 'F' || bank[1] != 'B' || bank[2] != 'Z' || bank[3] != 'B' || bank[4] != 1 || bank[5] != 0) {
+    return 0;
+  }
+  const uint32_t n = fastbezier_read16(bank + 6);
+  const uint32_t start = 8 + 8 * n;
+  if (start + 4 > size) {
+    return 0;
+  }
+  const uint32_t end = (uint32_t)size - 4;
+  uint32_t crc = 0xFFFFFFFF;
+  for (uint32_t i = 0; i < end; i++) {
+    crc ^= bank[i];
+    for (int j = 0; j < 8; j++) {
+      crc = (crc >> 1) ^ (0xEDB88320 & (0 - (crc & 1)));
+    }
+  }
+  if (~crc != fastbezier_read32(bank + end)) {
+    return 0;
+  }
+  for (uint32_t i = 0; i < n; i++) {
+    const uint8_t *e = bank + 8 + 8 * i;
+    const uint32_t off = fastbezier_read32(e);
+    const uint32_t len = fastbezier_read16(e + 4);
+    if (fastbezier_read16(e + 6) != 0 || off < start || (off & 1) || off > end || 2 * len > end - off || len < 3) {
+      return 0;
+    }
+    if (fastbezier_read16(bank + off + 2 * len - 2) != 65535) {
+      return 0;
+    }
+  }
+  return 1;
+}
+
+// fastbezier_bank_len returns the number of tables in the bank.
+static inline uint16_t fastbezier_bank_len(const uint8_t *bank) {
+  return fastbezier_read16(bank + 6);
+}
+
+// fastbezier_bank_eval evaluates the table id of the bank. id must be lower
+// than fastbezier_bank_len().
+static inline uint16_t fastbezier_bank_eval(const uint8_t *bank, uint16_t id, uint16_t x) {
+  const uint8_t *e = bank + 8 + 8 * (uint32_t)id;
+  const uint8_t *lut = bank + fastbezier_read32(e);
+  const uint32_t steps = (uint32_t)fastbezier_read16(e + 4) - 2;
+  const uint32_t x32 = x;
+  const uint32_t index = x32 * steps / 65535;
+  const uint32_t next_x = (index + 1) * 65535 / steps;
+  const uint32_t base_x = index * 65535 / steps;
+  const uint32_t a = (uint32_t)fastbezier_read16(lut + 2 * index) * (next_x - x32);
+  const uint32_t b = (uint32_t)fastbezier_read16(lut + 2 * index + 2) * (x32 - base_x);
+  return (uint16_t)((a + b) / (next_x - base_x));
+}
+#endif
+`
+
+// WriteC writes a C header declaring each table as a static const uint16_t
+// array.
+//
+// The header also defines fastbezier_eval() and the FASTBEZIER_EVAL(lut, x)
+// macro which return the same values as LUT.Eval, using only integer
+// arithmetic. It is safe to include multiple generated headers in the same
+// translation unit as long as the table names are different.
+func WriteC(w io.Writer, tables ...Table) error {
+	if err := validateTables(tables); err != nil {
+		return err
+	}
+	b := bufio.NewWriter(w)
+	writeCHeader(b)
+	io.WriteString(b, cEval)
+	for _, t := range tables {
+		io.WriteString(b, "\n")
+		if t.Desc != "" {
+			fmt.Fprintf(b, "// %s is %s.\n", t.Name, t.Desc)
+		}
+		fmt.Fprintf(b, "static const uint16_t %s[%d] = {", t.Name, len(t.LUT))
+		writeValues(b, t.LUT, "  ")
+		io.WriteString(b, "\n};\n")
+	}
+	return b.Flush()
+}
+
+// WriteCBank writes a C header to read a bank generated by MarshalBank with
+// the same tables, in the same order.
+//
+// The header defines FASTBEZIER_ID_<Name> for each table, and the functions
+// fastbezier_bank_valid(), fastbezier_bank_len() and fastbezier_bank_eval()
+// which returns the same values as LUT.Eval. The bank is read in place, e.g.
+// from flash, without any memory allocation.
+func WriteCBank(w io.Writer, tables ...Table) error {
+	if err := validateTables(tables); err != nil {
+		return err
+	}
+	b := bufio.NewWriter(w)
+	writeCHeader(b)
+	io.WriteString(b, cBank)
+	io.WriteString(b, "\n")
+	for i, t := range tables {
+		if t.Desc != "" {
+			fmt.Fprintf(b, "// %s\n", t.Desc)
+		}
+		fmt.Fprintf(b, "#define FASTBEZIER_ID_%s %d\n", t.Name, i)
+	}
+	return b.Flush()
+}
+
+func writeCHeader(w io.Writer) {
+	fmt.Fprintf(w, "// Code generated by fastbezier generator v%d; DO NOT EDIT.\n\n#pragma once\n\n#include <stddef.h>\n#include <stdint.h>\n\n", generatorVersion)
+}
@@ -0,0 +1,217 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteC(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteC(&b, NewTable("ease_in", Curve{0.42, 0, 1, 1}, 3, false)); err != nil {
+		t.Fatal(err)
+	}
+	expected := "// Code generated by fastbezier generator v1; DO NOT EDIT.\n\n#pragma once\n\n#include <stddef.h>\n#include <stdint.h>\n\n" + cEval + `
+// ease_in is cubic-bezier(0.42, 0, 1, 1), 3 steps, Make.
+static const uint16_t ease_in[4] = {
+  0, 20667, 65535, 65535,
+};
+`
+	if s := b.String(); s != expected {
+		t.Fatal(s)
+	}
+	if err := WriteC(&b); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// TestWriteC_Compile verifies that the C code returns the same values as
+// LUT.Eval.
+func TestWriteC_Compile(t *testing.T) {
+	tables := []Table{
+		NewTable("ease", Curve{0.25, 0.1, 0.25, 1}, 0, false),
+		NewTable("ease_in_out", Curve{0.42, 0, 0.58, 1}, 7, true),
+		{Name: "custom", LUT: LUT{65535, 0, 65535, 65535}},
+	}
+	var b bytes.Buffer
+	if err := WriteC(&b, tables...); err != nil {
+		t.Fatal(err)
+	}
+	main := `#include <stdio.h>
+#include "tables.h"
+#include "tables.h"
+
+int main(void) {
+  for (uint32_t x = 0; x < 65536; x += 7) {
+    printf("%u %u %u\n", FASTBEZIER_EVAL(ease, x), FASTBEZIER_EVAL(ease_in_out, x), FASTBEZIER_EVAL(custom, x));
+  }
+  return 0;
+}
+`
+	checkEvalOutput(t, runC(t, map[string]string{"tables.h": b.String(), "main.c": main}), tables)
+}
+
+func TestWriteCBank(t *testing.T) {
+	tables := genTables
+	var b bytes.Buffer
+	if err := WriteCBank(&b, tables...); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(b.String(), "#endif\n\n// cubic-bezier(0.42, 0, 1, 1), 32 steps, Make\n#define FASTBEZIER_ID_EaseIn 0\n// cubic-bezier(0, 0, 0.58, 1), 9 steps, MakeFast\n#define FASTBEZIER_ID_EaseOut 1\n#define FASTBEZIER_ID_Custom 2\n") {
+		t.Fatal(b.String())
+	}
+	if err := WriteCBank(&b); err == nil {
+		t.Fatal("expected error")
+	}
+
+	luts := make([]LUT, len(tables))
+	for i := range tables {
+		luts[i] = tables[i].LUT
+	}
+	bank, err := MarshalBank(luts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data bytes.Buffer
+	for i, v := range bank {
+		if i != 0 {
+			data.WriteString(", ")
+		}
+		data.WriteString(strconv.Itoa(int(v)))
+	}
+	// Prefix the bank with one byte to verify unaligned reads.
+	main := `#include <stdio.h>
+#include "tables.h"
+
+static uint8_t data[] = {0, ` + data.String() + `};
+
+int main(void) {
+  const uint8_t *bank = data + 1;
+  if (!fastbezier_bank_valid(bank, sizeof(data) - 1) || fastbezier_bank_len(bank) != 3) {
+    return 1;
+  }
+  for (uint32_t x = 0; x < 65536; x += 7) {
+    printf("%u %u %u\n",
+           fastbezier_bank_eval(bank, FASTBEZIER_ID_EaseIn, x),
+           fastbezier_bank_eval(bank, FASTBEZIER_ID_EaseOut, x),
+           fastbezier_bank_eval(bank, FASTBEZIER_ID_Custom, x));
+  }
+  data[20] ^= 1;
+  return fastbezier_bank_valid(bank, sizeof(data) - 1) ? 2 : 0;
+}
+`
+	checkEvalOutput(t, runC(t, map[string]string{"tables.h": b.String(), "main.c": main}), tables)
+}
+
+func TestWriteCBank_Invalid(t *testing.T) {
+	valid, err := MarshalBank(LUT{0, 32768, 65535, 65535})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each modified bank has a valid CRC so only the header or the index is
+	// invalid.
+	modify := func(f func(b []byte)) []byte {
+		b := append([]byte(nil), valid...)
+		f(b)
+		binary.LittleEndian.PutUint32(b[len(b)-4:], crc32.ChecksumIEEE(b[:len(b)-4]))
+		return b
+	}
+	banks := [][]byte{
+		valid,
+		modify(func(b []byte) { b[5] = 1 }),
+		modify(func(b []byte) { b[8] = 15 }),
+		modify(func(b []byte) { b[8] = 17 }),
+		modify(func(b []byte) { b[8] = 14 }),
+		modify(func(b []byte) { b[11] = 0x80 }),
+		modify(func(b []byte) { b[12] = 5 }),
+		modify(func(b []byte) { b[12] = 2 }),
+		modify(func(b []byte) { b[14] = 1 }),
+		modify(func(b []byte) { b[22] = 0 }),
+		modify(func(b []byte) { b[6] = 0xff; b[7] = 0xff }),
+	}
+	var arrays, checks bytes.Buffer
+	for i, bank := range banks {
+		if _, err := NewBank(bank); (err == nil) != (i == 0) {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		fmt.Fprintf(&arrays, "static const uint8_t bank%d[] = {", i)
+		for j, v := range bank {
+			if j != 0 {
+				arrays.WriteString(", ")
+			}
+			arrays.WriteString(strconv.Itoa(int(v)))
+		}
+		arrays.WriteString("};\n")
+		fmt.Fprintf(&checks, "  printf(\"%%d\\n\", fastbezier_bank_valid(bank%d, sizeof(bank%d)));\n", i, i)
+	}
+	var b bytes.Buffer
+	if err := WriteCBank(&b, Table{Name: "Linear", LUT: LUT{0, 32768, 65535, 65535}}); err != nil {
+		t.Fatal(err)
+	}
+	main := "#include <stdio.h>\n#include \"tables.h\"\n\n" + arrays.String() + "\nint main(void) {\n" + checks.String() + "  return 0;\n}\n"
+	out := runC(t, map[string]string{"tables.h": b.String(), "main.c": main})
+	if expected := "1\n" + strings.Repeat("0\n", len(banks)-1); out != expected {
+		t.Fatalf("%q", out)
+	}
+}
+
+// runC compiles main.c with the other files, e.g. tables.h, and returns the
+// output of its execution.
+func runC(t *testing.T, files map[string]string) string {
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("no C compiler")
+	}
+	d, err := ioutil.TempDir("", "fastbezier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(d, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exe := filepath.Join(d, "main")
+	if out, err := exec.Command(cc, "-std=c99", "-Wall", "-Wextra", "-Werror", "-I", d, "-o", exe, filepath.Join(d, "main.c")).CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	out, err := exec.Command(exe).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+// checkEvalOutput verifies lines of space separated values for each table
+// for x multiple of 7.
+func checkEvalOutput(t *testing.T, out string, tables []Table) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 65536/7+1 {
+		t.Fatalf("%d lines", len(lines))
+	}
+	for i, line := range lines {
+		x := uint16(i * 7)
+		for j, v := range strings.Fields(line) {
+			y, err := strconv.Atoi(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := tables[j].LUT.Eval(x); uint16(y) != expected {
+				t.Fatalf("%s: x=%d %d != %d", tables[j].Name, x, y, expected)
+			}
+		}
+	}
+}
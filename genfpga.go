@@ -0,0 +1,174 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// verilogKeywords are the Verilog-2005 keywords.
+var verilogKeywords = map[string]bool{
+	"always": true, "and": true, "assign": true, "automatic": true,
+	"begin": true, "buf": true, "bufif0": true, "bufif1": true, "case": true,
+	"casex": true, "casez": true, "cell": true, "cmos": true, "config": true,
+	"deassign": true, "default": true, "defparam": true, "design": true,
+	"disable": true, "edge": true, "else": true, "end": true, "endcase": true,
+	"endconfig": true, "endfunction": true, "endgenerate": true,
+	"endmodule": true, "endprimitive": true, "endspecify": true,
+	"endtable": true, "endtask": true, "event": true, "for": true, "force": true,
+	"forever": true, "fork": true, "function": true, "generate": true,
+	"genvar": true, "highz0": true, "highz1": true, "if": true, "ifnone": true,
+	"incdir": true, "include": true, "initial": true, "inout": true,
+	"input": true, "instance": true, "integer": true, "join": true,
+	"large": true, "liblist": true, "library": true, "localparam": true,
+	"macromodule": true, "medium": true, "module": true, "nand": true,
+	"negedge": true, "nmos": true, "nor": true, "noshowcancelled": true,
+	"not": true, "notif0": true, "notif1": true, "or": true, "output": true,
+	"parameter": true, "pmos": true, "posedge": true, "primitive": true,
+	"pull0": true, "pull1": true, "pulldown": true, "pullup": true,
+	"pulsestyle_ondetect": true, "pulsestyle_onevent": true, "rcmos": true,
+	"real": true, "realtime": true, "reg": true, "release": true, "repeat": true,
+	"rnmos": true, "rpmos": true, "rtran": true, "rtranif0": true,
+	"rtranif1": true, "scalared": true, "showcancelled": true, "signed": true,
+	"small": true, "specify": true, "specparam": true, "strong0": true,
+	"strong1": true, "supply0": true, "supply1": true, "table": true,
+	"task": true, "time": true, "tran": true, "tranif0": true, "tranif1": true,
+	"tri": true, "tri0": true, "tri1": true, "triand": true, "trior": true,
+	"trireg": true, "unsigned": true, "use": true, "uwire": true,
+	"vectored": true, "wait": true, "wand": true, "weak0": true, "weak1": true,
+	"while": true, "wire": true, "wor": true, "xnor": true, "xor": true,
+}
+
+// WriteCOE writes a Xilinx .coe memory initialization file.
+//
+// The file contains all the values of the LUT including the trailing 65535,
+// so the ROM depth is len(t.LUT).
+//
+// width is the ROM word width in bits, in the range [1, 32]. Each word is the
+// value as a fixed point fraction left aligned in the word: when width is
+// lower than 16, only the most significant bits are kept and when higher than
+// 16, the low bits are zero.
+func WriteCOE(w io.Writer, t Table, width int) error {
+	if err := validateROM(t, width); err != nil {
+		return err
+	}
+	b := bufio.NewWriter(w)
+	writeROMHeader(b, "; ", t, width)
+	io.WriteString(b, "memory_initialization_radix=16;\nmemory_initialization_vector=\n")
+	for i, y := range t.LUT {
+		sep := ",\n"
+		if i == len(t.LUT)-1 {
+			sep = ";\n"
+		}
+		fmt.Fprintf(b, "%0*X%s", hexDigits(width), romWord(y, width), sep)
+	}
+	return b.Flush()
+}
+
+// WriteMIF writes an Intel (Altera) .mif memory initialization file.
+//
+// The content and width are the same as WriteCOE.
+func WriteMIF(w io.Writer, t Table, width int) error {
+	if err := validateROM(t, width); err != nil {
+		return err
+	}
+	b := bufio.NewWriter(w)
+	writeROMHeader(b, "-- ", t, width)
+	fmt.Fprintf(b, "WIDTH=%d;\nDEPTH=%d;\n\nADDRESS_RADIX=UNS;\nDATA_RADIX=HEX;\n\nCONTENT BEGIN\n", width, len(t.LUT))
+	for i, y := range t.LUT {
+		fmt.Fprintf(b, "\t%d : %0*X;\n", i, hexDigits(width), romWord(y, width))
+	}
+	io.WriteString(b, "END;\n")
+	return b.Flush()
+}
+
+// WriteMem writes a .mem file to be loaded with Verilog's $readmemh.
+//
+// The content and width are the same as WriteCOE.
+func WriteMem(w io.Writer, t Table, width int) error {
+	if err := validateROM(t, width); err != nil {
+		return err
+	}
+	b := bufio.NewWriter(w)
+	writeROMHeader(b, "// ", t, width)
+	for _, y := range t.LUT {
+		fmt.Fprintf(b, "%0*X\n", hexDigits(width), romWord(y, width))
+	}
+	return b.Flush()
+}
+
+// WriteVerilog writes a reference Verilog module named after the table,
+// which reproduces LUT.Eval's interpolation.
+//
+// The module loads its ROM from memFile with $readmemh, which must be
+// generated by WriteMem with a width of 16 bits. y is updated on the rising
+// edge of clk. The divisions make it meant as a reference for simulation
+// rather than an efficient implementation.
+//
+// The table name must not be a Verilog keyword.
+func WriteVerilog(w io.Writer, t Table, memFile string) error {
+	if err := validateROM(t, 16); err != nil {
+		return err
+	}
+	if verilogKeywords[t.Name] {
+		return fmt.Errorf("table 0: invalid name %q", t.Name)
+	}
+	b := bufio.NewWriter(w)
+	writeROMHeader(b, "// ", t, 16)
+	fmt.Fprintf(b, `module %s (
+  input  wire        clk,
+  input  wire [15:0] x,
+  output reg  [15:0] y
+);
+  localparam [31:0] STEPS = %d;
+
+  reg [15:0] rom [0:%d];
+  initial $readmemh(%q, rom);
+
+  wire [31:0] x32 = {16'd0, x};
+  wire [31:0] index = x32 * STEPS / 32'd65535;
+  wire [31:0] next_x = (index + 32'd1) * 32'd65535 / STEPS;
+  wire [31:0] base_x = index * 32'd65535 / STEPS;
+  wire [31:0] a = rom[index] * (next_x - x32);
+  wire [31:0] b = rom[index + 32'd1] * (x32 - base_x);
+
+  always @(posedge clk) begin
+    y <= (a + b) / (next_x - base_x);
+  end
+endmodule
+`, t.Name, len(t.LUT)-2, len(t.LUT)-1, memFile)
+	return b.Flush()
+}
+
+func validateROM(t Table, width int) error {
+	if width < 1 || width > 32 {
+		return fmt.Errorf("width must be in range [1, 32], got %d", width)
+	}
+	return validateTables([]Table{t})
+}
+
+func writeROMHeader(w io.Writer, comment string, t Table, width int) {
+	fmt.Fprintf(w, "%sCode generated by fastbezier generator v%d; DO NOT EDIT.\n", comment, generatorVersion)
+	if t.Desc != "" {
+		fmt.Fprintf(w, "%s%s is %s.\n", comment, t.Name, t.Desc)
+	} else {
+		fmt.Fprintf(w, "%s%s\n", comment, t.Name)
+	}
+	fmt.Fprintf(w, "%sDepth %d, width %d bits.\n", comment, len(t.LUT), width)
+}
+
+// romWord returns y left aligned in a word of width bits.
+func romWord(y uint16, width int) uint32 {
+	if width < 16 {
+		return uint32(y) >> uint(16-width)
+	}
+	return uint32(y) << uint(width-16)
+}
+
+func hexDigits(width int) int {
+	return (width + 3) / 4
+}
@@ -0,0 +1,98 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteFPGA(t *testing.T) {
+	tbl := NewTable("ease_in", Curve{0.42, 0, 1, 1}, 5, false)
+	data := []struct {
+		file  string
+		write func(w io.Writer) error
+	}{
+		{"ease_in.coe", func(w io.Writer) error { return WriteCOE(w, tbl, 16) }},
+		{"ease_in_12.coe", func(w io.Writer) error { return WriteCOE(w, tbl, 12) }},
+		{"ease_in.mif", func(w io.Writer) error { return WriteMIF(w, tbl, 16) }},
+		{"ease_in_18.mif", func(w io.Writer) error { return WriteMIF(w, tbl, 18) }},
+		{"ease_in.mem", func(w io.Writer) error { return WriteMem(w, tbl, 16) }},
+		{"ease_in.v", func(w io.Writer) error { return WriteVerilog(w, tbl, "ease_in.mem") }},
+	}
+	for _, line := range data {
+		var b bytes.Buffer
+		if err := line.write(&b); err != nil {
+			t.Fatal(err)
+		}
+		golden(t, filepath.Join("testdata", line.file), b.Bytes())
+	}
+}
+
+func TestWriteMem(t *testing.T) {
+	tbl := NewTable("ease", Curve{0.25, 0.1, 0.25, 1}, 0, false)
+	for _, width := range []int{1, 8, 15, 16, 17, 32} {
+		var b bytes.Buffer
+		if err := WriteMem(&b, tbl, width); err != nil {
+			t.Fatal(err)
+		}
+		var values []uint16
+		for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+			if strings.HasPrefix(line, "//") {
+				continue
+			}
+			if len(line) != (width+3)/4 {
+				t.Fatalf("width %d: %q", width, line)
+			}
+			v, err := strconv.ParseUint(line, 16, 32)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if width < 16 {
+				v <<= uint(16 - width)
+			} else {
+				if v&(1<<uint(width-16)-1) != 0 {
+					t.Fatalf("width %d: %q is not left aligned", width, line)
+				}
+				v >>= uint(width - 16)
+			}
+			values = append(values, uint16(v))
+		}
+		if len(values) != len(tbl.LUT) {
+			t.Fatalf("width %d: %d values", width, len(values))
+		}
+		mask := uint16(0xFFFF)
+		if width < 16 {
+			mask <<= uint(16 - width)
+		}
+		for i, y := range tbl.LUT {
+			if values[i] != y&mask {
+				t.Fatalf("width %d: #%d: %d != %d", width, i, values[i], y&mask)
+			}
+		}
+	}
+}
+
+func TestWriteFPGA_Err(t *testing.T) {
+	tbl := Table{Name: "a", LUT: LUT{0, 32767, 65535, 65535}}
+	for _, width := range []int{0, 33} {
+		if err := WriteCOE(&bytes.Buffer{}, tbl, width); err == nil || err.Error() != "width must be in range [1, 32], got "+strconv.Itoa(width) {
+			t.Fatal(err)
+		}
+	}
+	if err := WriteMIF(&bytes.Buffer{}, Table{Name: "a"}, 16); err == nil || err.Error() != "table 0: invalid LUT" {
+		t.Fatal(err)
+	}
+	if err := WriteVerilog(&bytes.Buffer{}, Table{Name: "1a", LUT: tbl.LUT}, "a.mem"); err == nil || err.Error() != "table 0: invalid name \"1a\"" {
+		t.Fatal(err)
+	}
+	if err := WriteVerilog(&bytes.Buffer{}, Table{Name: "module", LUT: tbl.LUT}, "a.mem"); err == nil || err.Error() != "table 0: invalid name \"module\"" {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+)
+
+// WriteGo writes a Go source file for package pkg declaring each table as a
+// variable of type fastbezier.LUT.
+//
+// The output is gofmt'ed and starts with a "Code generated ... DO NOT EDIT."
+// header recording the generator version and how each table was generated,
+// so it is suitable for go:generate.
+func WriteGo(w io.Writer, pkg string, tables ...Table) error {
+	if !isIdent(pkg) || token.Lookup(pkg).IsKeyword() {
+		return fmt.Errorf("invalid package name %q", pkg)
+	}
+	if err := validateTables(tables); err != nil {
+		return err
+	}
+	for i, t := range tables {
+		if token.Lookup(t.Name).IsKeyword() {
+			return fmt.Errorf("table %d: invalid name %q", i, t.Name)
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by fastbezier generator v%d; DO NOT EDIT.\n//\n", generatorVersion)
+	for _, t := range tables {
+		if t.Desc != "" {
+			fmt.Fprintf(&b, "// %s: %s\n", t.Name, t.Desc)
+		} else {
+			fmt.Fprintf(&b, "// %s\n", t.Name)
+		}
+	}
+	fmt.Fprintf(&b, "\npackage %s\n\n", pkg)
+	typ := "LUT"
+	if pkg != "fastbezier" {
+		typ = "fastbezier.LUT"
+		io.WriteString(&b, "import \"github.com/maruel/fastbezier\"\n\n")
+	}
+	for _, t := range tables {
+		if t.Desc != "" {
+			fmt.Fprintf(&b, "// %s is %s.\n", t.Name, t.Desc)
+		}
+		fmt.Fprintf(&b, "var %s = %s{", t.Name, typ)
+		writeValues(&b, t.LUT, "")
+		io.WriteString(&b, "\n}\n\n")
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
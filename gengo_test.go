@@ -0,0 +1,102 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWriteGo(t *testing.T) {
+	var b bytes.Buffer
+	tables := []Table{
+		NewTable("EaseIn", Curve{0.42, 0, 1, 1}, 3, false),
+		{Name: "Custom", LUT: LUT{0, 100, 65535, 65535}},
+	}
+	if err := WriteGo(&b, "anim", tables...); err != nil {
+		t.Fatal(err)
+	}
+	expected := `// Code generated by fastbezier generator v1; DO NOT EDIT.
+//
+// EaseIn: cubic-bezier(0.42, 0, 1, 1), 3 steps, Make
+// Custom
+
+package anim
+
+import "github.com/maruel/fastbezier"
+
+// EaseIn is cubic-bezier(0.42, 0, 1, 1), 3 steps, Make.
+var EaseIn = fastbezier.LUT{
+	0, 20667, 65535, 65535,
+}
+
+var Custom = fastbezier.LUT{
+	0, 100, 65535, 65535,
+}
+`
+	if s := b.String(); s != expected {
+		t.Fatalf("%s", s)
+	}
+}
+
+func TestWriteGo_gofmt(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteGo(&b, "fastbezier", NewTable("Ease", Curve{0.25, 0.1, 0.25, 1}, 256, true)); err != nil {
+		t.Fatal(err)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, b.Bytes()) {
+		t.Fatal("output is not gofmt'ed")
+	}
+	if strings.Contains(b.String(), "import") {
+		t.Fatal("package fastbezier must not import itself")
+	}
+}
+
+func TestWriteGo_Err(t *testing.T) {
+	l := Make(0, 0, 1, 1, 3)
+	data := []struct {
+		pkg    string
+		tables []Table
+		err    string
+	}{
+		{"", []Table{{Name: "a", LUT: l}}, "invalid package name \"\""},
+		{"func", []Table{{Name: "a", LUT: l}}, "invalid package name \"func\""},
+		{"p", nil, "at least one table is required"},
+		{"p", []Table{{Name: "a", LUT: l}, {Name: "var", LUT: l}}, "table 1: invalid name \"var\""},
+	}
+	for i, line := range data {
+		if err := WriteGo(&bytes.Buffer{}, line.pkg, line.tables...); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func ExampleWriteGo() {
+	t := NewTable("EaseInOut", Curve{0.42, 0, 0.58, 1}, 10, false)
+	if err := WriteGo(os.Stdout, "anim", t); err != nil {
+		return
+	}
+	// Output:
+	// // Code generated by fastbezier generator v1; DO NOT EDIT.
+	// //
+	// // EaseInOut: cubic-bezier(0.42, 0, 0.58, 1), 10 steps, Make
+	//
+	// package anim
+	//
+	// import "github.com/maruel/fastbezier"
+	//
+	// // EaseInOut is cubic-bezier(0.42, 0, 0.58, 1), 10 steps, Make.
+	// var EaseInOut = fastbezier.LUT{
+	// 	0, 1603, 6646, 15189, 26539, 38996, 50346, 58889,
+	// 	63932, 65535, 65535,
+	// }
+}
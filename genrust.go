@@ -0,0 +1,107 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// rustEval is the Rust implementation of LUT.Eval. It must return the exact
+// same values. None of the operations can overflow.
+const rustEval = `/// Evaluates a table including the trailing 65535. It returns the same
+/// values as fastbezier's LUT.Eval.
+pub fn eval(lut: &[u16], x: u16) -> u16 {
+    let steps = (lut.len() - 2) as u32;
+    let x = x as u32;
+    let index = x * steps / 65535;
+    let next_x = (index + 1) * 65535 / steps;
+    let base_x = index * 65535 / steps;
+    let a = lut[index as usize] as u32 * (next_x - x);
+    let b = lut[index as usize + 1] as u32 * (x - base_x);
+    ((a + b) / (next_x - base_x)) as u16
+}
+`
+
+// rustKeywords are the Rust strict and reserved keywords.
+var rustKeywords = map[string]bool{
+	"abstract": true, "as": true, "async": true, "await": true, "become": true,
+	"box": true, "break": true, "const": true, "continue": true, "crate": true,
+	"do": true, "dyn": true, "else": true, "enum": true, "extern": true,
+	"false": true, "final": true, "fn": true, "for": true, "if": true,
+	"impl": true, "in": true, "let": true, "loop": true, "macro": true,
+	"match": true, "mod": true, "move": true, "mut": true, "override": true,
+	"priv": true, "pub": true, "ref": true, "return": true, "self": true,
+	"static": true, "struct": true, "super": true, "trait": true, "true": true,
+	"try": true, "type": true, "typeof": true, "unsafe": true, "unsized": true,
+	"use": true, "virtual": true, "where": true, "while": true, "yield": true,
+}
+
+// WriteRust writes a Rust module usable in no_std crates.
+//
+// Each table is declared as `pub static NAME: [u16; N]` with a function
+// `pub fn name(x: u16) -> u16` evaluating it, where NAME and name are the
+// table name converted to upper and lower snake case, e.g. EaseIn becomes
+// EASE_IN and ease_in. The module also declares
+// `pub fn eval(lut: &[u16], x: u16) -> u16` which returns the same values as
+// LUT.Eval.
+func WriteRust(w io.Writer, tables ...Table) error {
+	if err := validateTables(tables); err != nil {
+		return err
+	}
+	names := map[string]string{"eval": "the eval function"}
+	for i, t := range tables {
+		n := snakeCase(t.Name)
+		if rustKeywords[n] {
+			return fmt.Errorf("table %d: invalid name %q", i, t.Name)
+		}
+		if other, ok := names[n]; ok {
+			return fmt.Errorf("table %d: name %q conflicts with %s", i, t.Name, other)
+		}
+		names[n] = strconv.Quote(t.Name)
+	}
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "// Code generated by fastbezier generator v%d; DO NOT EDIT.\n\n", generatorVersion)
+	io.WriteString(b, rustEval)
+	for _, t := range tables {
+		n := snakeCase(t.Name)
+		io.WriteString(b, "\n")
+		if t.Desc != "" {
+			fmt.Fprintf(b, "/// %s is %s.\n", strings.ToUpper(n), t.Desc)
+		}
+		fmt.Fprintf(b, "pub static %s: [u16; %d] = [", strings.ToUpper(n), len(t.LUT))
+		writeValues(b, t.LUT, "    ")
+		fmt.Fprintf(b, "\n];\n\n/// Evaluates %s.\npub fn %s(x: u16) -> u16 {\n    eval(&%s, x)\n}\n", strings.ToUpper(n), n, strings.ToUpper(n))
+	}
+	return b.Flush()
+}
+
+// snakeCase converts an identifier to lower snake case, e.g. "EaseInOut" and
+// "EASE_IN_OUT" both become "ease_in_out".
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUpper(c) && i != 0 && s[i-1] != '_' {
+			// Start a new word on a lower to upper transition, or for the last
+			// upper case letter of an acronym, e.g. "HTTPServer".
+			if !isUpper(s[i-1]) || i+1 < len(s) && s[i+1] >= 'a' && s[i+1] <= 'z' {
+				b.WriteByte('_')
+			}
+		}
+		if isUpper(c) {
+			c += 'a' - 'A'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
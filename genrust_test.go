@@ -0,0 +1,125 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/")
+
+func TestWriteRust(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteRust(&b, genTables...); err != nil {
+		t.Fatal(err)
+	}
+	golden(t, filepath.Join("testdata", "tables.rs"), b.Bytes())
+}
+
+// TestWriteRust_Compile verifies that the Rust code returns the same values
+// as LUT.Eval.
+func TestWriteRust_Compile(t *testing.T) {
+	rustc, err := exec.LookPath("rustc")
+	if err != nil {
+		t.Skip("no Rust compiler")
+	}
+	d, err := ioutil.TempDir("", "fastbezier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+	var b bytes.Buffer
+	if err := WriteRust(&b, genTables...); err != nil {
+		t.Fatal(err)
+	}
+	main := `mod tables;
+
+fn main() {
+    let mut x: u32 = 0;
+    while x < 65536 {
+        let x16 = x as u16;
+        println!("{} {} {}", tables::ease_in(x16), tables::ease_out(x16), tables::custom(x16));
+        x += 7;
+    }
+}
+`
+	if err := ioutil.WriteFile(filepath.Join(d, "tables.rs"), b.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(d, "main.rs"), []byte(main), 0600); err != nil {
+		t.Fatal(err)
+	}
+	exe := filepath.Join(d, "main")
+	// Debug mode panics on integer overflow.
+	if out, err := exec.Command(rustc, "-D", "warnings", "-C", "debug-assertions=on", "-o", exe, filepath.Join(d, "main.rs")).CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	out, err := exec.Command(exe).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkEvalOutput(t, string(out), genTables)
+}
+
+func TestWriteRust_Err(t *testing.T) {
+	l := LUT{0, 32767, 65535, 65535}
+	data := []struct {
+		tables []Table
+		err    string
+	}{
+		{nil, "at least one table is required"},
+		{[]Table{{Name: "Type", LUT: l}}, "table 0: invalid name \"Type\""},
+		{[]Table{{Name: "Eval", LUT: l}}, "table 0: name \"Eval\" conflicts with the eval function"},
+		{[]Table{{Name: "EaseIn", LUT: l}, {Name: "ease_in", LUT: l}}, "table 1: name \"ease_in\" conflicts with \"EaseIn\""},
+	}
+	for i, line := range data {
+		if err := WriteRust(&bytes.Buffer{}, line.tables...); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	data := []struct {
+		in, expected string
+	}{
+		{"a", "a"},
+		{"EaseIn", "ease_in"},
+		{"easeInOut", "ease_in_out"},
+		{"EASE_IN_OUT", "ease_in_out"},
+		{"HTTPServer", "http_server"},
+		{"Ease2In", "ease2_in"},
+		{"_Ease", "_ease"},
+	}
+	for i, line := range data {
+		if s := snakeCase(line.in); s != line.expected {
+			t.Errorf("#%d: snakeCase(%q) = %q; expected %q", i, line.in, s, line.expected)
+		}
+	}
+}
+
+// golden compares got with the content of the file at path, or updates the
+// file when -update is specified.
+func golden(t *testing.T, path string, got []byte) {
+	if *update {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; run go test -update to create it", err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Fatalf("%s is out of date; run go test -update if the change is intended; got:\n%s", path, got)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// OvershootLUT is the same as LUT except that values can go below 0 and above
+// 65535.
+//
+// The input is still constrained in the range [0, 65535]. 65535 still means
+// 1., so a value of 72088 means an overshoot of 10%.
+//
+// It is useful for curves like springs, back and elastic easing that go past
+// their target.
+type OvershootLUT []int32
+
+func (l OvershootLUT) String() string {
+	b := bytes.NewBufferString("OvershootLUT{")
+	steps := len(l) - 2
+	for i, y := range l {
+		x := i * 65535 / steps
+		fmt.Fprintf(b, "(%d, %d)", x, y)
+		if i == steps {
+			break
+		}
+		io.WriteString(b, ", ")
+	}
+	io.WriteString(b, "}")
+	return b.String()
+}
+
+func (l OvershootLUT) Eval(x uint16) int32 {
+	steps := int64(len(l) - 2)
+	x64 := int64(x)
+	index := x64 * steps / 65535
+	nextX := (index + 1) * 65535 / steps
+	baseX := index * 65535 / steps
+	a := int64(l[index]) * (nextX - x64)
+	b := int64(l[index+1]) * (x64 - baseX)
+	return int32((a + b) / (nextX - baseX))
+}
+
+// Clamp returns a LUT with the values clamped to [0, 65535].
+func (l OvershootLUT) Clamp() LUT {
+	out := make(LUT, len(l))
+	for i, y := range l {
+		if y < 0 {
+			y = 0
+		} else if y > 65535 {
+			y = 65535
+		}
+		out[i] = uint16(y)
+	}
+	return out
+}
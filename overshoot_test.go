@@ -0,0 +1,53 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import "testing"
+
+func TestOvershootLUT(t *testing.T) {
+	// Must match LUT within the [0, 65535] range.
+	for _, c := range curves {
+		l := Make(c.x0, c.y0, c.x1, c.y1, 0)
+		o := make(OvershootLUT, len(l))
+		for i, y := range l {
+			o[i] = int32(y)
+		}
+		for x := 0; x < 65536; x++ {
+			if a, b := l.Eval(uint16(x)), o.Eval(uint16(x)); int32(a) != b {
+				t.Fatalf("x=%d: %d != %d", x, a, b)
+			}
+		}
+		if s := o.Clamp().String(); s != l.String() {
+			t.Fatalf("%s != %s", s, l)
+		}
+	}
+}
+
+func TestOvershootLUT_Overshoot(t *testing.T) {
+	o := OvershootLUT{0, -1000, 80000, 65535, 65535}
+	data := []struct {
+		x        uint16
+		expected int32
+	}{
+		{0, 0},
+		{10922, -499},
+		{21845, -1000},
+		{32767, 39498},
+		{43690, 80000},
+		{54612, 72767},
+		{65535, 65535},
+	}
+	for i, line := range data {
+		if v := o.Eval(line.x); v != line.expected {
+			t.Errorf("#%d: Eval(%d) = %d; expected %d", i, line.x, v, line.expected)
+		}
+	}
+	if s := o.String(); s != "OvershootLUT{(0, 0), (21845, -1000), (43690, 80000), (65535, 65535)}" {
+		t.Fatal(s)
+	}
+	if s := o.Clamp().String(); s != "LUT{(0, 0), (21845, 0), (43690, 65535), (65535, 65535)}" {
+		t.Fatal(s)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import "math"
+
+// ParseLUT parses the output of LUT.String() back into a LUT.
+//
+// It also accepts the String() output of the TableFull and PointsFull
+// evaluators, e.g. "TableFull{(0, 0), ..., (65535, 65535)}". The x
+// coordinates must be the uniform spacing LUT.Eval assumes, i.e. point i of n
+// must have x == i*65535/(n-1). This is generally not the case for PointsFull
+// since its points are distributed along the curve.
+//
+// Errors are of type *ParseError.
+func ParseLUT(s string) (LUT, error) {
+	p := scanner{s: s}
+	p.skipSpace()
+	start := p.pos
+	p.ident()
+	switch name := p.s[start:p.pos]; name {
+	case "LUT", "TableFull", "PointsFull":
+	case "":
+		if p.eof() {
+			return nil, p.errorf(start, "expected LUT, got end of input")
+		}
+		return nil, p.errorf(start, "expected LUT, got %q", p.s[start])
+	default:
+		return nil, p.errorf(start, "unknown type %q", name)
+	}
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+	var l LUT
+	// xs are the x coordinates and their offset in s, they are validated once
+	// the number of points is known.
+	type coord struct {
+		x   int
+		off int
+	}
+	var xs []coord
+	for {
+		if len(l) != 0 {
+			p.skipSpace()
+			if p.peek() == '}' {
+				break
+			}
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		off := p.pos
+		x, err := p.uint16()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+		y, err := p.uint16()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+		if len(l) == math.MaxUint16 {
+			return nil, p.errorf(off, "too many points")
+		}
+		l = append(l, y)
+		xs = append(xs, coord{int(x), off})
+	}
+	p.pos++
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf(p.pos, "unexpected %q", p.s[p.pos:])
+	}
+	steps := len(l) - 1
+	if steps < 1 {
+		return nil, p.errorf(xs[0].off, "at least 2 points are required")
+	}
+	for i, c := range xs {
+		if expected := i * 65535 / steps; c.x != expected {
+			return nil, p.errorf(c.off, "point %d: x=%d, expected %d", i, c.x, expected)
+		}
+	}
+	// Adds a second 65535 to speed up Eval(); otherwise x==65535 has to be
+	// special cased which slows it down.
+	return append(l, 65535), nil
+}
+
+// uint16 parses an integer in the range [0, 65535].
+func (p *scanner) uint16() (uint16, error) {
+	p.skipSpace()
+	start := p.pos
+	v, err := p.number()
+	if err != nil {
+		return 0, err
+	}
+	if v != float64(int(v)) || v < 0 || v > 65535 {
+		return 0, p.errorf(start, "expected an integer in range [0, 65535], got %q", p.s[start:p.pos])
+	}
+	return uint16(v), nil
+}
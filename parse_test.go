@@ -0,0 +1,105 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maruel/fastbezier/internal/rejected"
+)
+
+func TestParseLUT(t *testing.T) {
+	for _, c := range curves {
+		for _, steps := range []uint16{3, 0, 256} {
+			l := Make(c.x0, c.y0, c.x1, c.y1, steps)
+			got, err := ParseLUT(l.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, l) {
+				t.Fatalf("%s != %s", got, l)
+			}
+			// TableFull uses the same layout.
+			got, err = ParseLUT(rejected.MakeTableFull(c.x0, c.y0, c.x1, c.y1, steps).String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, l) {
+				t.Fatalf("%s != %s", got, l)
+			}
+		}
+	}
+	got, err := ParseLUT(" LUT {\n  (0,10),\n  (32767, 20)  , (65535,30)\n}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, LUT{10, 20, 30, 65535}) {
+		t.Fatal(got)
+	}
+}
+
+func TestParseLUT_Err(t *testing.T) {
+	data := []struct {
+		in  string
+		err string
+	}{
+		{"", "1:1: expected LUT, got end of input"},
+		{"{", "1:1: expected LUT, got '{'"},
+		{"Table{(0, 0)}", "1:1: unknown type \"Table\""},
+		{"LUT(0, 0)", "1:4: expected '{', got '('"},
+		{"LUT{}", "1:5: expected '(', got '}'"},
+		{"LUT{(0, 0)}", "1:6: at least 2 points are required"},
+		{"LUT{(0, 0), (65535, 65535)", "1:27: expected ',', got end of input"},
+		{"LUT{(0, 0) (65535, 65535)}", "1:12: expected ',', got '('"},
+		{"LUT{(0, 0), (65535, 65536)}", "1:21: expected an integer in range [0, 65535], got \"65536\""},
+		{"LUT{(0, 0), (1.5, 65535)}", "1:14: expected an integer in range [0, 65535], got \"1.5\""},
+		{"LUT{(0, 0), (x, 65535)}", "1:14: expected a number"},
+		{"LUT{(0, 0), (65535, 65535)} x", "1:29: unexpected \"x\""},
+		{"LUT{\n(0, 0),\n(32768, 1),\n(65535, 65535)}", "3:2: point 1: x=32768, expected 32767"},
+		{"PointsFull{(0, 0), (20000, 40000), (65535, 65535)}", "1:21: point 1: x=20000, expected 32767"},
+	}
+	for i, line := range data {
+		l, err := ParseLUT(line.in)
+		if err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+		if _, ok := err.(*ParseError); !ok {
+			t.Errorf("#%d: unexpected error type %T", i, err)
+		}
+		if l != nil {
+			t.Errorf("#%d: unexpected LUT %v", i, l)
+		}
+	}
+	// Too many points.
+	b := strings.Builder{}
+	b.WriteString("LUT{")
+	for i := 0; i < 65536; i++ {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "(%d, 0)", i)
+	}
+	b.WriteString("}")
+	if _, err := ParseLUT(b.String()); err == nil || !strings.HasSuffix(err.Error(), ": too many points") {
+		t.Fatal(err)
+	}
+}
+
+func ExampleParseLUT() {
+	l, err := ParseLUT("LUT{(0, 0), (21845, 8000), (43690, 50000), (65535, 65535)}")
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("%d\n", l.Eval(32767))
+	_, err = ParseLUT("LUT{(0, 0), (21845, 8000), (43691, 50000), (65535, 65535)}")
+	fmt.Printf("%v\n", err)
+	// Output:
+	// 28999
+	// 1:29: point 2: x=43691, expected 43690
+}
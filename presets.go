@@ -0,0 +1,222 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"math"
+)
+
+// Preset is one of Robert Penner's easing functions.
+//
+// See http://robertpenner.com/easing/ for the original definitions.
+type Preset int
+
+// Preset values. Each family has the In, Out and InOut variants, in this
+// order.
+const (
+	SineIn Preset = iota
+	SineOut
+	SineInOut
+	QuadIn
+	QuadOut
+	QuadInOut
+	CubicIn
+	CubicOut
+	CubicInOut
+	QuartIn
+	QuartOut
+	QuartInOut
+	QuintIn
+	QuintOut
+	QuintInOut
+	ExpoIn
+	ExpoOut
+	ExpoInOut
+	CircIn
+	CircOut
+	CircInOut
+	BackIn
+	BackOut
+	BackInOut
+	ElasticIn
+	ElasticOut
+	ElasticInOut
+	BounceIn
+	BounceOut
+	BounceInOut
+	numPresets
+)
+
+// Presets returns all the presets.
+func Presets() []Preset {
+	out := make([]Preset, numPresets)
+	for i := range out {
+		out[i] = Preset(i)
+	}
+	return out
+}
+
+func (p Preset) String() string {
+	if p < 0 || p >= numPresets {
+		return fmt.Sprintf("Preset(%d)", int(p))
+	}
+	return families[p/3].name + [...]string{"In", "Out", "InOut"}[p%3]
+}
+
+// Func returns the closed form of the easing function over [0, 1].
+//
+// Back and Elastic return values outside of [0, 1]. An invalid preset
+// returns the linear function.
+func (p Preset) Func() func(x float64) float64 {
+	if p < 0 || p >= numPresets {
+		// Make invalid `p` value silently work instead of crashing or inducing
+		// unnecessary error handling.
+		return func(x float64) float64 {
+			return x
+		}
+	}
+	// Penner's Back and Elastic InOut variants use different constants than
+	// their In variant.
+	switch p {
+	case BackInOut:
+		return backInOut
+	case ElasticInOut:
+		return elasticInOut
+	}
+	in := families[p/3].in
+	switch p % 3 {
+	case 0:
+		return in
+	case 1:
+		return func(x float64) float64 {
+			return 1 - in(1-x)
+		}
+	default:
+		return func(x float64) float64 {
+			if x < 0.5 {
+				return in(2*x) / 2
+			}
+			return 1 - in(2-2*x)/2
+		}
+	}
+}
+
+// LUT returns a LUT sampling the preset.
+//
+// Values outside of [0, 1], as with Back and Elastic, are clamped. Use
+// Overshoot to keep them. Elastic and Bounce need a large number of steps to
+// be reasonably precise.
+func (p Preset) LUT(steps uint16) LUT {
+	return MakeFunc(p.Func(), steps)
+}
+
+// Overshoot returns an OvershootLUT sampling the preset.
+func (p Preset) Overshoot(steps uint16) OvershootLUT {
+	if steps < 3 {
+		// Make invalid `steps` value silently work instead of crashing or inducing
+		// unnecessary error handling.
+		steps = 32
+	}
+	f := p.Func()
+	stepsm1 := 1. / float64(steps-1)
+	l := make(OvershootLUT, steps, steps+1)
+	for i := range l {
+		l[i] = int32(math.Floor(f(float64(i)*stepsm1)*65535. + 0.5))
+	}
+	// Adds a second 65535 to speed up Eval(); otherwise x==65535 has to be
+	// special cased which slows it down.
+	l = append(l, 65535)
+	return l
+}
+
+// families are the "In" variants of each preset family.
+var families = [...]struct {
+	name string
+	in   func(x float64) float64
+}{
+	{"Sine", func(x float64) float64 {
+		return 1 - math.Cos(x*math.Pi/2)
+	}},
+	{"Quad", func(x float64) float64 {
+		return x * x
+	}},
+	{"Cubic", func(x float64) float64 {
+		return x * x * x
+	}},
+	{"Quart", func(x float64) float64 {
+		return x * x * x * x
+	}},
+	{"Quint", func(x float64) float64 {
+		return x * x * x * x * x
+	}},
+	{"Expo", func(x float64) float64 {
+		if x <= 0 {
+			return 0
+		}
+		return math.Pow(2, 10*x-10)
+	}},
+	{"Circ", func(x float64) float64 {
+		return 1 - math.Sqrt(1-x*x)
+	}},
+	{"Back", func(x float64) float64 {
+		const c1 = 1.70158
+		return (c1+1)*x*x*x - c1*x*x
+	}},
+	{"Elastic", func(x float64) float64 {
+		if x <= 0 {
+			return 0
+		}
+		if x >= 1 {
+			return 1
+		}
+		return -math.Pow(2, 10*x-10) * math.Sin((10*x-10.75)*(2*math.Pi/3))
+	}},
+	{"Bounce", func(x float64) float64 {
+		return 1 - bounceOut(1-x)
+	}},
+}
+
+func bounceOut(x float64) float64 {
+	const n1 = 7.5625
+	const d1 = 2.75
+	switch {
+	case x < 1/d1:
+		return n1 * x * x
+	case x < 2/d1:
+		x -= 1.5 / d1
+		return n1*x*x + 0.75
+	case x < 2.5/d1:
+		x -= 2.25 / d1
+		return n1*x*x + 0.9375
+	default:
+		x -= 2.625 / d1
+		return n1*x*x + 0.984375
+	}
+}
+
+func backInOut(x float64) float64 {
+	const c2 = 1.70158 * 1.525
+	if x < 0.5 {
+		x *= 2
+		return x * x * ((c2+1)*x - c2) / 2
+	}
+	x = 2*x - 2
+	return (x*x*((c2+1)*x+c2) + 2) / 2
+}
+
+func elasticInOut(x float64) float64 {
+	const c5 = 2 * math.Pi / 4.5
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	if x < 0.5 {
+		return -math.Pow(2, 20*x-10) * math.Sin((20*x-11.125)*c5) / 2
+	}
+	return math.Pow(2, -20*x+10)*math.Sin((20*x-11.125)*c5)/2 + 1
+}
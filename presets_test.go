@@ -0,0 +1,151 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPresets(t *testing.T) {
+	if len(Presets()) != 30 {
+		t.Fatalf("unexpected number of presets %d", len(Presets()))
+	}
+	for _, p := range Presets() {
+		f := p.Func()
+		if y := f(0); math.Abs(y) > 1e-9 {
+			t.Errorf("%s(0) = %g", p, y)
+		}
+		if y := f(1); math.Abs(y-1) > 1e-9 {
+			t.Errorf("%s(1) = %g", p, y)
+		}
+		l := p.LUT(1024)
+		if len(l) != 1025 {
+			t.Errorf("%s: unexpected length %d", p, len(l))
+		}
+		if l.Eval(0) != 0 || l.Eval(65535) != 65535 {
+			t.Errorf("%s: bad end points", p)
+		}
+		// InOut variants are symmetrical around the center.
+		if p%3 == 2 {
+			if y := f(0.5); math.Abs(y-0.5) > 1e-9 {
+				t.Errorf("%s(0.5) = %g", p, y)
+			}
+		}
+		// Out is the reverse of In.
+		if p%3 == 1 {
+			in := (p - 1).Func()
+			for x := 0.; x <= 1; x += 0.125 {
+				if a, b := f(x), 1-in(1-x); math.Abs(a-b) > 1e-9 {
+					t.Errorf("%s(%g) = %g; expected %g", p, x, a, b)
+				}
+			}
+		}
+	}
+}
+
+func TestPresets_Values(t *testing.T) {
+	data := []struct {
+		p        Preset
+		x        float64
+		expected float64
+	}{
+		{SineIn, 0.5, 1 - math.Sqrt2/2},
+		{QuadIn, 0.5, 0.25},
+		{QuadOut, 0.5, 0.75},
+		{CubicInOut, 0.25, 0.0625},
+		{QuartIn, 0.5, 0.0625},
+		{QuintOut, 0.5, 1 - 1./32},
+		{ExpoIn, 0.5, 1. / 32},
+		{CircIn, 0.6, 0.2},
+		{BackIn, 0.5, -0.0876975},
+		{BackInOut, 0.25, -0.0996818},
+		{BackInOut, 0.75, 1.0996818},
+		{ElasticOut, 0.1, 1.25},
+		{ElasticInOut, 0.25, 0.0119694},
+		{ElasticInOut, 0.45, 0.0434120},
+		{ElasticInOut, 0.55, 0.9565880},
+		{BounceOut, 0.5, 0.765625},
+		{BounceIn, 0.5, 0.234375},
+	}
+	for i, line := range data {
+		if y := line.p.Func()(line.x); math.Abs(y-line.expected) > 1e-6 {
+			t.Errorf("#%d: %s(%g) = %g; expected %g", i, line.p, line.x, y, line.expected)
+		}
+	}
+}
+
+func TestPresets_Invalid(t *testing.T) {
+	// Invalid presets are linear.
+	for _, p := range []Preset{-1, numPresets, 99} {
+		if y := p.Func()(0.25); y != 0.25 {
+			t.Errorf("%s(0.25) = %g", p, y)
+		}
+		if l := p.LUT(3); l.String() != "LUT{(0, 0), (32767, 32768), (65535, 65535)}" {
+			t.Errorf("%s: %s", p, l)
+		}
+		if l := p.Overshoot(3); len(l) != 4 || l[1] != 32768 {
+			t.Errorf("%s: %v", p, l)
+		}
+	}
+}
+
+func TestPresets_Overshoot(t *testing.T) {
+	l := BackIn.Overshoot(0)
+	if len(l) != 33 {
+		t.Fatalf("unexpected length %d", len(l))
+	}
+	min := int32(0)
+	for _, y := range l {
+		if y < min {
+			min = y
+		}
+	}
+	// Back dips by about 10% before going up.
+	if min > -6000 || min < -7000 {
+		t.Fatalf("unexpected minimum %d", min)
+	}
+	if c := BackIn.LUT(0); c.String() != l.Clamp().String() {
+		t.Fatalf("%s != %s", c, l.Clamp())
+	}
+	e := ElasticOut.Overshoot(256)
+	max := int32(0)
+	for _, y := range e {
+		if y > max {
+			max = y
+		}
+	}
+	if max < 65535*13/10 {
+		t.Fatalf("unexpected maximum %d", max)
+	}
+}
+
+func TestPreset_String(t *testing.T) {
+	data := []struct {
+		p        Preset
+		expected string
+	}{
+		{SineIn, "SineIn"},
+		{QuadOut, "QuadOut"},
+		{BounceInOut, "BounceInOut"},
+		{-1, "Preset(-1)"},
+		{numPresets, "Preset(30)"},
+	}
+	for i, line := range data {
+		if s := line.p.String(); s != line.expected {
+			t.Errorf("#%d: %q != %q", i, s, line.expected)
+		}
+	}
+}
+
+func ExamplePreset_LUT() {
+	l := QuadIn.LUT(6)
+	fmt.Printf("%s\n", l)
+	fmt.Printf("%d\n", l.Eval(32767))
+	// Output:
+	// LUT{(0, 0), (13107, 2621), (26214, 10486), (39321, 23593), (52428, 41942), (65535, 65535)}
+	// 17039
+}
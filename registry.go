@@ -0,0 +1,107 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Registry is a set of named curves, used to find which one is the closest
+// to an arbitrary curve.
+//
+// The zero value is an empty registry. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	entries []registryEntry
+}
+
+type registryEntry struct {
+	name string
+	e    Evaluator
+}
+
+// NewRegistry returns a Registry initialized with the CSS cubic bezier
+// keywords; linear, ease, ease-in, ease-out and ease-in-out.
+func NewRegistry() *Registry {
+	r := &Registry{}
+	for _, k := range cssKeywords {
+		if k.e.Steps.N == 0 {
+			r.entries = append(r.entries, registryEntry{k.name, Curve{k.e.X0, k.e.Y0, k.e.X1, k.e.Y1}.Precise()})
+		}
+	}
+	return r
+}
+
+// Add adds a named curve.
+func (r *Registry) Add(name string, e Evaluator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range r.entries {
+		if entry.name == name {
+			return fmt.Errorf("%q is already registered", name)
+		}
+	}
+	r.entries = append(r.entries, registryEntry{name, e})
+	return nil
+}
+
+// Names returns the names of the registered curves, in the order they were
+// added.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]string, len(r.entries))
+	for i, entry := range r.entries {
+		out[i] = entry.name
+	}
+	return out
+}
+
+// Nearest returns the registered curve closest to e and the maximum
+// difference between the two over the whole uint16 domain.
+//
+// When two curves are equally close, the first one added wins. It returns an
+// empty name if the registry is empty.
+func (r *Registry) Nearest(e Evaluator) (string, uint16) {
+	// Evaluate e once as it may be slow.
+	ref := make([]uint16, 65536)
+	for x := range ref {
+		ref[x] = e.Eval(uint16(x))
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name := ""
+	best := 65536
+	for _, entry := range r.entries {
+		max := 0
+		for x, y := range ref {
+			d := int(entry.e.Eval(uint16(x))) - int(y)
+			if d < 0 {
+				d = -d
+			}
+			if d > max {
+				if max = d; max >= best {
+					// Can't be better.
+					break
+				}
+			}
+		}
+		if max < best {
+			name = entry.name
+			best = max
+		}
+	}
+	if name == "" {
+		return "", 0
+	}
+	return name, uint16(best)
+}
+
+// NearestBezier is the same as Nearest for the cubic bezier curve (0, 0),
+// (x0, y0), (x1, y1), (1, 1).
+func (r *Registry) NearestBezier(x0, y0, x1, y1 float32) (string, uint16) {
+	return r.Nearest(Curve{x0, y0, x1, y1}.Precise())
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	expected := []string{"linear", "ease", "ease-in", "ease-out", "ease-in-out"}
+	if names := r.Names(); fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Fatalf("%v", names)
+	}
+	data := []struct {
+		x0, y0, x1, y1 float32
+		name           string
+		maxDelta       uint16
+	}{
+		{0, 0, 1, 1, "linear", 0},
+		{0.42, 0, 0.58, 1, "ease-in-out", 0},
+		{0.01, 0, 0.58, 1, "ease-out", 431},
+		{0.4, 0, 0.6, 1, "ease-in-out", 445},
+		{0.25, 0.1, 0.25, 0.95, "ease", 1457},
+	}
+	for i, line := range data {
+		name, d := r.NearestBezier(line.x0, line.y0, line.x1, line.y1)
+		if name != line.name || d != line.maxDelta {
+			t.Errorf("#%d: %q, %d; expected %q, %d", i, name, d, line.name, line.maxDelta)
+		}
+	}
+
+	// A LUT is within its approximation error of the precise curve.
+	name, d := r.Nearest(Make(0.42, 0, 1, 1, 0))
+	if name != "ease-in" || d != 47 {
+		t.Fatalf("%q, %d", name, d)
+	}
+}
+
+func TestRegistry_Add(t *testing.T) {
+	var r Registry
+	if name, d := r.Nearest(Make(0.42, 0, 1, 1, 0)); name != "" || d != 0 {
+		t.Fatalf("%q, %d", name, d)
+	}
+	for _, p := range Presets() {
+		if err := r.Add(p.String(), p.LUT(256)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Add("QuadIn", QuadIn.LUT(0)); err == nil || err.Error() != "\"QuadIn\" is already registered" {
+		t.Fatal(err)
+	}
+	name, d := r.Nearest(MakeFunc(func(x float64) float64 { return x * x * x }, 0))
+	if name != "CubicIn" || d > 100 {
+		t.Fatalf("%q, %d", name, d)
+	}
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	r := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := r.Add(fmt.Sprintf("steps%d", i), Steps{uint16(i + 1), JumpEnd}); err != nil {
+				t.Error(err)
+			}
+			r.Nearest(Steps{2, JumpEnd})
+		}(i)
+	}
+	wg.Wait()
+	if name, d := r.Nearest(Steps{2, JumpEnd}); name != "steps1" || d != 0 {
+		t.Fatalf("%q, %d", name, d)
+	}
+}
+
+func ExampleRegistry_NearestBezier() {
+	r := NewRegistry()
+	name, maxDelta := r.NearestBezier(0.4, 0, 0.6, 1)
+	fmt.Printf("%s %.1f%%\n", name, float32(maxDelta)*100./65535.)
+	// Output:
+	// ease-in-out 0.7%
+}
@@ -0,0 +1,121 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"sync"
+	"time"
+)
+
+// TrackID identifies a track in a Scheduler.
+//
+// The zero value is never returned by Scheduler.Add.
+type TrackID uint32
+
+// Scheduler advances many independent animations in lockstep, without
+// requiring a goroutine per animation.
+//
+// Each track animates one channel, an index in the slice passed to Advance.
+// When multiple tracks animate the same channel, the most recently added one
+// wins.
+//
+// The zero value is ready to use. It is safe to call Add and Cancel
+// concurrently with Advance.
+type Scheduler struct {
+	mu     sync.Mutex
+	lastID TrackID
+	tracks []track
+}
+
+type track struct {
+	id       TrackID
+	channel  int
+	l        LUT
+	start    time.Time
+	d        time.Duration
+	from, to uint16
+}
+
+// Add schedules channel to go from `from` to `to` following l, starting at
+// start and lasting d.
+//
+// A d of zero or less jumps to `to` at start. Returns 0 if channel is
+// negative.
+func (s *Scheduler) Add(channel int, l LUT, start time.Time, d time.Duration, from, to uint16) TrackID {
+	if channel < 0 {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	s.tracks = append(s.tracks, track{s.lastID, channel, l, start, d, from, to})
+	return s.lastID
+}
+
+// Cancel removes a track, leaving its channel at its current value.
+//
+// Returns false if the track had already completed or was already canceled.
+func (s *Scheduler) Cancel(id TrackID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.tracks {
+		if s.tracks[i].id == id {
+			copy(s.tracks[i:], s.tracks[i+1:])
+			s.tracks = s.tracks[:len(s.tracks)-1]
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of tracks pending or in progress.
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tracks)
+}
+
+// Advance writes the value at time now of each started track into
+// out[channel].
+//
+// Tracks that haven't started yet leave their channel untouched. Tracks that
+// completed write their final value and are removed. Tracks whose channel is
+// not within out still progress but do not write anything.
+//
+// Returns the number of tracks still pending or in progress.
+func (s *Scheduler) Advance(now time.Time, out []uint16) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j := 0
+	for _, t := range s.tracks {
+		elapsed := now.Sub(t.start)
+		if elapsed < 0 {
+			s.tracks[j] = t
+			j++
+			continue
+		}
+		if t.channel < len(out) {
+			out[t.channel] = lerp16(t.from, t.to, t.l.Eval(progress(elapsed, t.d)))
+		}
+		if elapsed < t.d {
+			s.tracks[j] = t
+			j++
+		}
+	}
+	for i := j; i < len(s.tracks); i++ {
+		// Release the LUT references.
+		s.tracks[i] = track{}
+	}
+	s.tracks = s.tracks[:j]
+	return j
+}
+
+// lerp16 interpolates between from and to, where y is the position in the
+// uint16 domain.
+func lerp16(from, to, y uint16) uint16 {
+	a := uint32(from) * (65535 - uint32(y))
+	b := uint32(to) * uint32(y)
+	return uint16((a + b) / 65535)
+}
@@ -0,0 +1,194 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := Make(0.42, 0, 0.58, 1, 0)
+	var s Scheduler
+	s.Add(0, l, start, time.Second, 0, 65535)
+	s.Add(1, l, start.Add(time.Second), time.Second, 1000, 0)
+	s.Add(2, l, start, 2*time.Second, 65535, 0)
+	out := []uint16{42, 42, 42}
+
+	if n := s.Advance(start, out); n != 3 {
+		t.Fatalf("expected 3 tracks, got %d", n)
+	}
+	if expected := []uint16{0, 42, 65535}; !equalUint16(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+
+	if n := s.Advance(start.Add(500*time.Millisecond), out); n != 3 {
+		t.Fatalf("expected 3 tracks, got %d", n)
+	}
+	if expected := []uint16{l.Eval(32767), 42, lerp16(65535, 0, l.Eval(16383))}; !equalUint16(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+
+	if n := s.Advance(start.Add(1500*time.Millisecond), out); n != 2 {
+		t.Fatalf("expected 2 tracks, got %d", n)
+	}
+	if expected := []uint16{65535, lerp16(1000, 0, l.Eval(32767)), lerp16(65535, 0, l.Eval(49151))}; !equalUint16(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+
+	if n := s.Advance(start.Add(3*time.Second), out); n != 0 {
+		t.Fatalf("expected 0 tracks, got %d", n)
+	}
+	if expected := []uint16{65535, 0, 0}; !equalUint16(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+}
+
+func TestScheduler_Instant(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	var s Scheduler
+	s.Add(0, Make(0.42, 0, 0.58, 1, 0), start, 0, 100, 60000)
+	out := []uint16{42}
+	if n := s.Advance(start.Add(-time.Second), out); n != 1 || out[0] != 42 {
+		t.Fatalf("%d %v", n, out)
+	}
+	if n := s.Advance(start, out); n != 0 || out[0] != 60000 {
+		t.Fatalf("%d %v", n, out)
+	}
+}
+
+func TestScheduler_Channel(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	var s Scheduler
+	if id := s.Add(-1, nil, start, time.Second, 0, 65535); id != 0 {
+		t.Fatalf("unexpected id %d", id)
+	}
+	// Out of range channels are ignored.
+	if id := s.Add(2, Make(0.42, 0, 0.58, 1, 0), start, time.Second, 0, 65535); id == 0 {
+		t.Fatal("unexpected id")
+	}
+	out := []uint16{42, 42}
+	if n := s.Advance(start.Add(500*time.Millisecond), out); n != 1 || !equalUint16(out, []uint16{42, 42}) {
+		t.Fatalf("%d %v", n, out)
+	}
+	if n := s.Advance(start.Add(time.Second), out); n != 0 {
+		t.Fatalf("%d", n)
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := Make(0.42, 0, 0.58, 1, 0)
+	var s Scheduler
+	a := s.Add(0, l, start, time.Second, 0, 65535)
+	b := s.Add(0, l, start, time.Second, 65535, 0)
+	out := []uint16{42}
+	s.Advance(start.Add(500*time.Millisecond), out)
+	if v := lerp16(65535, 0, l.Eval(32767)); out[0] != v {
+		t.Fatalf("expected the last track to win; %d != %d", out[0], v)
+	}
+	if !s.Cancel(b) {
+		t.Fatal("failed to cancel")
+	}
+	if s.Cancel(b) {
+		t.Fatal("canceled twice")
+	}
+	s.Advance(start.Add(500*time.Millisecond), out)
+	if v := l.Eval(32767); out[0] != v {
+		t.Fatalf("%d != %d", out[0], v)
+	}
+	if !s.Cancel(a) {
+		t.Fatal("failed to cancel")
+	}
+	s.Advance(start.Add(time.Second), out)
+	if v := l.Eval(32767); out[0] != v {
+		t.Fatalf("canceled track kept running; %d != %d", out[0], v)
+	}
+	if s.Len() != 0 {
+		t.Fatal("expected no track")
+	}
+}
+
+func TestScheduler_Concurrent(t *testing.T) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := Make(0.42, 0, 0.58, 1, 0)
+	var s Scheduler
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id := s.Add(i*100+j, l, start, time.Second, 0, 65535)
+				if j%2 == 0 {
+					s.Cancel(id)
+				}
+			}
+		}(i)
+	}
+	out := make([]uint16, 800)
+	for i := 0; i < 10; i++ {
+		s.Advance(start.Add(time.Duration(i)*100*time.Millisecond), out)
+	}
+	wg.Wait()
+	if n := s.Len(); n != 400 {
+		t.Fatalf("expected 400 tracks, got %d", n)
+	}
+	if n := s.Advance(start.Add(time.Second), out); n != 0 {
+		t.Fatalf("expected 0 tracks, got %d", n)
+	}
+	for i, v := range out {
+		if i%2 == 1 && v != 65535 {
+			t.Fatalf("channel %d: %d", i, v)
+		}
+	}
+}
+
+func TestLerp16(t *testing.T) {
+	data := []struct {
+		from, to, y, expected uint16
+	}{
+		{0, 65535, 0, 0},
+		{0, 65535, 12345, 12345},
+		{0, 65535, 65535, 65535},
+		{65535, 0, 0, 65535},
+		{65535, 0, 65535, 0},
+		{1000, 2000, 32767, 1499},
+		{2000, 1000, 32767, 1500},
+	}
+	for i, line := range data {
+		if v := lerp16(line.from, line.to, line.y); v != line.expected {
+			t.Errorf("#%d: lerp16(%d, %d, %d) = %d; expected %d", i, line.from, line.to, line.y, v, line.expected)
+		}
+	}
+}
+
+func BenchmarkScheduler_Advance_1000(b *testing.B) {
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := Make(0.42, 0, 0.58, 1, 0)
+	var s Scheduler
+	for i := 0; i < 1000; i++ {
+		s.Add(i, l, start, time.Hour, 0, 65535)
+	}
+	out := make([]uint16, 1000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		s.Advance(start.Add(time.Duration(n)), out)
+	}
+}
+
+func equalUint16(a, b []uint16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Point is a point in the uint16 domain.
+type Point struct {
+	X, Y uint16
+}
+
+// Interpolation is the interpolation between points used by MakePoints.
+type Interpolation int
+
+const (
+	// Monotone is a monotone cubic interpolation (Fritsch-Carlson). The curve
+	// is smooth and never overshoots; it is monotonic wherever the points are.
+	Monotone Interpolation = iota
+	// PiecewiseLinear joins the points with straight lines.
+	PiecewiseLinear
+)
+
+func (i Interpolation) String() string {
+	switch i {
+	case Monotone:
+		return "Monotone"
+	case PiecewiseLinear:
+		return "PiecewiseLinear"
+	default:
+		return fmt.Sprintf("Interpolation(%d)", int(i))
+	}
+}
+
+// MakePoints returns a LUT going through the points, like the curves tool of
+// photo editors.
+//
+// Points must be sorted by strictly increasing X. The curve is flat before
+// the first point and after the last one.
+//
+// Memory allocation is 2*(steps+1) bytes.
+func MakePoints(points []Point, i Interpolation, steps uint16) (LUT, error) {
+	if len(points) < 2 {
+		return nil, errors.New("at least 2 points are required")
+	}
+	for j := 1; j < len(points); j++ {
+		if points[j].X <= points[j-1].X {
+			return nil, fmt.Errorf("point %d: x=%d must be greater than x=%d of point %d", j, points[j].X, points[j-1].X, j-1)
+		}
+	}
+	x := make([]float64, len(points))
+	y := make([]float64, len(points))
+	for j, p := range points {
+		x[j] = float64(p.X) / 65535.
+		y[j] = float64(p.Y) / 65535.
+	}
+	var m []float64
+	switch i {
+	case Monotone:
+		m = monotoneTangents(x, y)
+	case PiecewiseLinear:
+	default:
+		return nil, fmt.Errorf("unknown interpolation %s", i)
+	}
+	return MakeFunc(func(v float64) float64 {
+		if v <= x[0] {
+			return y[0]
+		}
+		last := len(x) - 1
+		if v >= x[last] {
+			return y[last]
+		}
+		// Find the segment [k, k+1] containing v.
+		k := sort.SearchFloat64s(x, v)
+		if x[k] != v {
+			k--
+		}
+		h := x[k+1] - x[k]
+		t := (v - x[k]) / h
+		if m == nil {
+			return y[k] + (y[k+1]-y[k])*t
+		}
+		// Cubic Hermite spline.
+		t2 := t * t
+		t3 := t2 * t
+		return (2*t3-3*t2+1)*y[k] + (t3-2*t2+t)*h*m[k] + (-2*t3+3*t2)*y[k+1] + (t3-t2)*h*m[k+1]
+	}, steps), nil
+}
+
+// monotoneTangents returns the tangents at each point per the Fritsch-Carlson
+// method.
+func monotoneTangents(x, y []float64) []float64 {
+	n := len(x)
+	d := make([]float64, n-1)
+	for k := range d {
+		d[k] = (y[k+1] - y[k]) / (x[k+1] - x[k])
+	}
+	m := make([]float64, n)
+	m[0] = d[0]
+	m[n-1] = d[n-2]
+	for k := 1; k < n-1; k++ {
+		if d[k-1]*d[k] > 0 {
+			m[k] = (d[k-1] + d[k]) / 2
+		}
+	}
+	for k := range d {
+		if d[k] == 0 {
+			m[k] = 0
+			m[k+1] = 0
+			continue
+		}
+		a := m[k] / d[k]
+		b := m[k+1] / d[k]
+		if s := a*a + b*b; s > 9 {
+			tau := 3 / math.Sqrt(s)
+			m[k] = tau * a * d[k]
+			m[k+1] = tau * b * d[k]
+		}
+	}
+	return m
+}
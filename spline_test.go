@@ -0,0 +1,104 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMakePoints(t *testing.T) {
+	// Points are on the LUT grid so they must be exact.
+	points := []Point{{0, 0}, {13107, 6000}, {26214, 40000}, {39321, 41000}, {52428, 41000}, {65535, 65535}}
+	for _, i := range []Interpolation{Monotone, PiecewiseLinear} {
+		l, err := MakePoints(points, i, 6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, p := range points {
+			if l[j] != p.Y {
+				t.Errorf("%s: point %d: %d != %d", i, j, l[j], p.Y)
+			}
+		}
+		l, err = MakePoints(points, i, 257)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Monotonic points must give a monotonic curve.
+		for x := 1; x < 65536; x++ {
+			if l.Eval(uint16(x)) < l.Eval(uint16(x-1)) {
+				t.Fatalf("%s: not monotonic at x=%d", i, x)
+			}
+		}
+	}
+}
+
+func TestMakePoints_Flat(t *testing.T) {
+	// Flat before the first point and after the last one, and no overshoot on
+	// a plateau.
+	points := []Point{{16384, 10000}, {32768, 50000}, {40000, 50000}, {49151, 60000}}
+	l, err := MakePoints(points, Monotone, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := 0; x < 65536; x++ {
+		y := l.Eval(uint16(x))
+		// Skip one LUT interval around each point.
+		switch {
+		case x < 16384-64:
+			if y != 10000 {
+				t.Fatalf("x=%d: %d", x, y)
+			}
+		case x > 32768+64 && x < 40000-64:
+			if y != 50000 {
+				t.Fatalf("x=%d: %d", x, y)
+			}
+		case x > 49151+64:
+			if y != 60000 {
+				t.Fatalf("x=%d: %d", x, y)
+			}
+		}
+	}
+}
+
+func TestMakePoints_PiecewiseLinear(t *testing.T) {
+	l, err := MakePoints([]Point{{0, 65535}, {65535, 0}}, PiecewiseLinear, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := l.String(); s != "LUT{(0, 65535), (16383, 49151), (32767, 32768), (49151, 16384), (65535, 0)}" {
+		t.Fatal(s)
+	}
+}
+
+func TestMakePoints_Err(t *testing.T) {
+	data := []struct {
+		points []Point
+		i      Interpolation
+		err    string
+	}{
+		{nil, Monotone, "at least 2 points are required"},
+		{[]Point{{0, 0}}, Monotone, "at least 2 points are required"},
+		{[]Point{{0, 0}, {10, 0}, {10, 5}}, Monotone, "point 2: x=10 must be greater than x=10 of point 1"},
+		{[]Point{{0, 0}, {10, 0}}, Interpolation(10), "unknown interpolation Interpolation(10)"},
+	}
+	for j, line := range data {
+		if _, err := MakePoints(line.points, line.i, 0); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", j, err)
+		}
+	}
+}
+
+func ExampleMakePoints() {
+	// S-shaped tone curve increasing contrast.
+	l, err := MakePoints([]Point{{0, 0}, {16384, 12000}, {49151, 53535}, {65535, 65535}}, Monotone, 9)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("%s\n", l)
+	// Output:
+	// LUT{(0, 0), (8191, 5452), (16383, 12000), (24575, 21562), (32767, 32767), (40959, 43973), (49151, 53535), (57343, 60083), (65535, 65535)}
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// maxSettle is the longest settle time for MakeSpring.
+const maxSettle = time.Minute
+
+// MakeSpring returns an OvershootLUT of a damped spring going from 0 to 1
+// released at rest, along with the time it takes to settle.
+//
+// The parameters are in SI units; stiffness in N/m, damping in N*s/m and mass
+// in kg. The spring is considered settled once its remaining energy cannot
+// move it by more than half a unit in the uint16 domain.
+//
+// The LUT covers the whole settle time, so the animation should last for the
+// returned duration to look physically correct.
+func MakeSpring(stiffness, damping, mass float32, steps uint16) (OvershootLUT, time.Duration, error) {
+	if stiffness <= 0 || mass <= 0 {
+		return nil, 0, errors.New("stiffness and mass must be positive")
+	}
+	if damping <= 0 {
+		// An undamped spring oscillates forever.
+		return nil, 0, errors.New("damping must be positive")
+	}
+	if steps < 3 {
+		// Make invalid `steps` value silently work instead of crashing or inducing
+		// unnecessary error handling.
+		steps = 32
+	}
+	sp := newSpring(float64(stiffness), float64(damping), float64(mass))
+	// Energy below which the amplitude is less than half a unit.
+	const eps = 0.5 / 65535.
+	settled := 0.5 * sp.k * eps * eps
+
+	// The energy of a damped spring never increases, so the settle time can be
+	// found with a binary search.
+	lo, hi := 0., maxSettle.Seconds()
+	if sp.energy(hi) >= settled {
+		return nil, 0, fmt.Errorf("spring doesn't settle within %s", maxSettle)
+	}
+	for i := 0; i < 64 && hi-lo > 1e-9; i++ {
+		if mid := (lo + hi) / 2; sp.energy(mid) < settled {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	settle := time.Duration(hi * float64(time.Second))
+
+	l := make(OvershootLUT, steps, steps+1)
+	for i := range l {
+		x, _ := sp.eval(hi * float64(i) / float64(steps-1))
+		l[i] = int32(math.Floor((1+x)*65535. + 0.5))
+	}
+	l[steps-1] = 65535
+	// Adds a second 65535 to speed up Eval(); otherwise x==65535 has to be
+	// special cased which slows it down.
+	l = append(l, 65535)
+	return l, settle, nil
+}
+
+// spring is the closed form solution of a damped spring released at rest at
+// a distance of 1 from its equilibrium.
+type spring struct {
+	k, m float64
+	// gamma is c/2m and omega is sqrt(k/m).
+	gamma, omega float64
+	// omegaD is the damped angular frequency when underdamped; r1 and r2 are
+	// the decay rates when overdamped.
+	omegaD, r1, r2 float64
+}
+
+func newSpring(k, c, m float64) *spring {
+	s := &spring{k: k, m: m, gamma: c / (2 * m), omega: math.Sqrt(k / m)}
+	switch {
+	case s.gamma < s.omega:
+		s.omegaD = math.Sqrt(s.omega*s.omega - s.gamma*s.gamma)
+	case s.gamma > s.omega:
+		d := math.Sqrt(s.gamma*s.gamma - s.omega*s.omega)
+		s.r2 = -s.gamma - d
+		// r1*r2 == omega^2; this avoids the cancellation of -gamma+d.
+		s.r1 = s.omega * s.omega / s.r2
+	}
+	return s
+}
+
+// eval returns the offset from the equilibrium and the velocity at time t.
+func (s *spring) eval(t float64) (float64, float64) {
+	switch {
+	case s.omegaD != 0:
+		e := math.Exp(-s.gamma * t)
+		sin, cos := math.Sincos(s.omegaD * t)
+		return -e * (cos + s.gamma/s.omegaD*sin), e * s.omega * s.omega / s.omegaD * sin
+	case s.r1 != 0:
+		a := s.r2 / (s.r1 - s.r2)
+		b := -s.r1 / (s.r1 - s.r2)
+		e1 := math.Exp(s.r1 * t)
+		e2 := math.Exp(s.r2 * t)
+		return a*e1 + b*e2, a*s.r1*e1 + b*s.r2*e2
+	default:
+		e := math.Exp(-s.omega * t)
+		return -(1 + s.omega*t) * e, s.omega * s.omega * t * e
+	}
+}
+
+// energy returns the remaining energy at time t.
+func (s *spring) energy(t float64) float64 {
+	x, v := s.eval(t)
+	return 0.5*s.k*x*x + 0.5*s.m*v*v
+}
@@ -0,0 +1,152 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMakeSpring_CriticallyDamped(t *testing.T) {
+	// omega = 10 rad/s, critically damped.
+	l, settle, err := MakeSpring(100, 20, 1, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 257 {
+		t.Fatalf("unexpected length %d", len(l))
+	}
+	// Analytic solution: x(t) = 1 - (1 + wt)e^-wt.
+	if settle < 1200*time.Millisecond || settle > 1600*time.Millisecond {
+		t.Fatalf("unexpected settle time %s", settle)
+	}
+	for i := 0; i < 65536; i += 128 {
+		s := float64(i) / 65535. * settle.Seconds()
+		expected := 65535. * (1 - (1+10*s)*math.Exp(-10*s))
+		y := l.Eval(uint16(i))
+		if y < 0 || y > 65535 {
+			t.Fatalf("critically damped spring must not overshoot: %d", y)
+		}
+		if math.Abs(float64(y)-expected) > 100 {
+			t.Fatalf("x=%d: expected %g, got %d", i, expected, y)
+		}
+	}
+	if l.Eval(0) != 0 || l.Eval(65535) != 65535 {
+		t.Fatalf("bad end points: %s", l)
+	}
+}
+
+func TestMakeSpring_Underdamped(t *testing.T) {
+	l, settle, err := MakeSpring(170, 20, 1, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	max := int32(0)
+	for _, y := range l {
+		if y > max {
+			max = y
+		}
+	}
+	// Damping ratio ~= 0.77 so the overshoot is about 2%.
+	if max < 66535 || max > 67535 {
+		t.Fatalf("unexpected overshoot %d", max)
+	}
+	if settle < time.Second || settle > 3*time.Second {
+		t.Fatalf("unexpected settle time %s", settle)
+	}
+
+	// Use a lot of steps to not miss the peak.
+	l, _, err = MakeSpring(200, 5, 1, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Clamp().Eval(65535) != 65535 {
+		t.Fatal("bad clamp")
+	}
+	max = 0
+	for _, y := range l {
+		if y > max {
+			max = y
+		}
+	}
+	// Damping ratio ~= 0.18 so the overshoot is about 56%.
+	if max < 65535*15/10 || max > 65535*16/10 {
+		t.Fatalf("unexpected overshoot %d", max)
+	}
+}
+
+func TestMakeSpring_Overdamped(t *testing.T) {
+	// The fast decay rate is 9e4/s while the slow one is about 11/s.
+	l, settle, err := MakeSpring(1e6, 9e4, 1, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settle < time.Second || settle > 1100*time.Millisecond {
+		t.Fatalf("unexpected settle time %s", settle)
+	}
+	for i := 1; i < len(l); i++ {
+		if l[i] < l[i-1] || l[i] > 65535 {
+			t.Fatalf("not monotonic: %s", l)
+		}
+	}
+}
+
+func TestMakeSpring_LongSettle(t *testing.T) {
+	// Stiff and lightly damped; it oscillates about 2000 times before
+	// settling.
+	const k, c, m = 1e6, 2., 1.
+	l, settle, err := MakeSpring(k, c, m, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settle < 10*time.Second || settle > 15*time.Second {
+		t.Fatalf("unexpected settle time %s", settle)
+	}
+	omega := math.Sqrt(k / m)
+	zeta := c / (2 * math.Sqrt(k*m))
+	omegaD := omega * math.Sqrt(1-zeta*zeta)
+	for i, y := range l[:len(l)-1] {
+		ts := settle.Seconds() * float64(i) / float64(len(l)-2)
+		expected := 1 - math.Exp(-zeta*omega*ts)*(math.Cos(omegaD*ts)+zeta*omega/omegaD*math.Sin(omegaD*ts))
+		if d := math.Abs(float64(y) - expected*65535); d > 1 {
+			t.Fatalf("#%d: %d != %g", i, y, expected*65535)
+		}
+	}
+}
+
+func TestMakeSpring_Err(t *testing.T) {
+	data := []struct {
+		stiffness, damping, mass float32
+		err                      string
+	}{
+		{0, 1, 1, "stiffness and mass must be positive"},
+		{1, 1, 0, "stiffness and mass must be positive"},
+		{1, -1, 1, "damping must be positive"},
+		{1, 0, 1, "damping must be positive"},
+		{1, 0.001, 1, "spring doesn't settle within 1m0s"},
+		{1, 1e8, 1, "spring doesn't settle within 1m0s"},
+		{1e-30, 1, 1, "spring doesn't settle within 1m0s"},
+	}
+	for i, line := range data {
+		if _, _, err := MakeSpring(line.stiffness, line.damping, line.mass, 0); err == nil || err.Error() != line.err {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func ExampleMakeSpring() {
+	l, settle, err := MakeSpring(200, 10, 1, 8)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("%s\n", settle.Round(time.Millisecond))
+	fmt.Printf("%s\n", l)
+	// Output:
+	// 2.327s
+	// OvershootLUT{(0, 0), (9362, 73865), (18724, 66914), (28086, 65072), (37448, 65540), (46810, 65551), (56172, 65533), (65535, 65535)}
+}
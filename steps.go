@@ -0,0 +1,92 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import "fmt"
+
+// StepPosition is the position of the jumps of a Steps easing.
+type StepPosition int
+
+// StepPosition values, as defined by CSS.
+const (
+	// JumpEnd jumps at the end of each interval; the first step is 0. It is
+	// the default and is also named "end".
+	JumpEnd StepPosition = iota
+	// JumpStart jumps at the start of each interval; the last step is 1. It is
+	// also named "start".
+	JumpStart
+	// JumpNone doesn't jump at either end; steps include both 0 and 1.
+	JumpNone
+	// JumpBoth jumps at both ends; steps include neither 0 nor 1.
+	JumpBoth
+)
+
+func (s StepPosition) String() string {
+	switch s {
+	case JumpEnd:
+		return "jump-end"
+	case JumpStart:
+		return "jump-start"
+	case JumpNone:
+		return "jump-none"
+	case JumpBoth:
+		return "jump-both"
+	default:
+		return fmt.Sprintf("StepPosition(%d)", int(s))
+	}
+}
+
+// Steps is a stepped easing in the uint16 domain, as defined by the CSS
+// steps() function.
+//
+// See https://www.w3.org/TR/css-easing-1/#step-easing-functions.
+//
+// It implements Evaluator.
+type Steps struct {
+	// N is the number of intervals.
+	N   uint16
+	Pos StepPosition
+}
+
+func (s Steps) String() string {
+	if s.Pos == JumpEnd {
+		return fmt.Sprintf("steps(%d)", s.N)
+	}
+	return fmt.Sprintf("steps(%d, %s)", s.N, s.Pos)
+}
+
+// Eval returns the value of the step at x.
+//
+// Steps are output rounded to the nearest uint16 value. The boundaries are
+// exact; the output changes at the first x where x*N/65535 reaches the next
+// integer.
+func (s Steps) Eval(x uint16) uint16 {
+	n := uint32(s.N)
+	if n == 0 {
+		// Make invalid `N` value silently work instead of crashing or inducing
+		// unnecessary error handling.
+		n = 1
+	}
+	jumps := n
+	current := uint32(x) * n / 65535
+	switch s.Pos {
+	case JumpStart:
+		current++
+	case JumpNone:
+		if n == 1 {
+			// Same as above.
+			n = 2
+			current = uint32(x) * n / 65535
+		}
+		jumps = n - 1
+	case JumpBoth:
+		current++
+		jumps = n + 1
+	}
+	if current > jumps {
+		current = jumps
+	}
+	return uint16((current*65535 + jumps/2) / jumps)
+}
@@ -0,0 +1,135 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"testing"
+)
+
+var _ Evaluator = Steps{}
+
+func TestSteps(t *testing.T) {
+	// Examples from https://www.w3.org/TR/css-easing-1/#step-easing-functions,
+	// expressed in the uint16 domain where 0.25 is 16384.
+	data := []struct {
+		s        Steps
+		x        uint16
+		expected uint16
+	}{
+		// steps(4) and steps(4, end).
+		{Steps{4, JumpEnd}, 0, 0},
+		{Steps{4, JumpEnd}, 16383, 0},
+		{Steps{4, JumpEnd}, 16384, 16384},
+		{Steps{4, JumpEnd}, 32767, 16384},
+		{Steps{4, JumpEnd}, 32768, 32768},
+		{Steps{4, JumpEnd}, 65534, 49151},
+		{Steps{4, JumpEnd}, 65535, 65535},
+		// steps(4, start).
+		{Steps{4, JumpStart}, 0, 16384},
+		{Steps{4, JumpStart}, 16383, 16384},
+		{Steps{4, JumpStart}, 16384, 32768},
+		{Steps{4, JumpStart}, 65534, 65535},
+		{Steps{4, JumpStart}, 65535, 65535},
+		// steps(5, jump-none); 5 steps of 0, 0.25, 0.5, 0.75 and 1.
+		{Steps{5, JumpNone}, 0, 0},
+		{Steps{5, JumpNone}, 13106, 0},
+		{Steps{5, JumpNone}, 13107, 16384},
+		{Steps{5, JumpNone}, 52427, 49151},
+		{Steps{5, JumpNone}, 52428, 65535},
+		{Steps{5, JumpNone}, 65535, 65535},
+		// steps(3, jump-both); 4 jumps to 0.25, 0.5, 0.75 and 1.
+		{Steps{3, JumpBoth}, 0, 16384},
+		{Steps{3, JumpBoth}, 21844, 16384},
+		{Steps{3, JumpBoth}, 21845, 32768},
+		{Steps{3, JumpBoth}, 43690, 49151},
+		{Steps{3, JumpBoth}, 65534, 49151},
+		{Steps{3, JumpBoth}, 65535, 65535},
+		// step-start and step-end.
+		{Steps{1, JumpStart}, 0, 65535},
+		{Steps{1, JumpEnd}, 0, 0},
+		{Steps{1, JumpEnd}, 65534, 0},
+		{Steps{1, JumpEnd}, 65535, 65535},
+		// steps(1, jump-both) outputs 0.5 during the whole interval.
+		{Steps{1, JumpBoth}, 0, 32768},
+		{Steps{1, JumpBoth}, 65534, 32768},
+		{Steps{1, JumpBoth}, 65535, 65535},
+		// Invalid values are silently fixed.
+		{Steps{0, JumpEnd}, 65534, 0},
+		{Steps{1, JumpNone}, 32767, 0},
+		{Steps{1, JumpNone}, 32768, 65535},
+	}
+	for i, line := range data {
+		if y := line.s.Eval(line.x); y != line.expected {
+			t.Errorf("#%d: %s.Eval(%d) = %d; expected %d", i, line.s, line.x, y, line.expected)
+		}
+	}
+}
+
+func TestSteps_Monotonic(t *testing.T) {
+	for _, pos := range []StepPosition{JumpEnd, JumpStart, JumpNone, JumpBoth} {
+		for n := uint16(1); n < 100; n++ {
+			s := Steps{n, pos}
+			last := s.Eval(0)
+			changes := 0
+			for x := 1; x < 65536; x++ {
+				y := s.Eval(uint16(x))
+				if y < last {
+					t.Fatalf("%s is not monotonic at %d", s, x)
+				}
+				if y != last {
+					changes++
+				}
+				last = y
+			}
+			// The jump at 1 happens at x=65535 except for jump-start.
+			expected := int(n)
+			switch pos {
+			case JumpStart:
+				expected = int(n) - 1
+			case JumpNone:
+				if n == 1 {
+					expected = 1
+				} else {
+					expected = int(n) - 1
+				}
+			}
+			if changes != expected {
+				t.Fatalf("%s changed %d times; expected %d", s, changes, expected)
+			}
+		}
+	}
+}
+
+func TestSteps_String(t *testing.T) {
+	data := []struct {
+		s        Steps
+		expected string
+	}{
+		{Steps{4, JumpEnd}, "steps(4)"},
+		{Steps{4, JumpStart}, "steps(4, jump-start)"},
+		{Steps{2, JumpNone}, "steps(2, jump-none)"},
+		{Steps{1, JumpBoth}, "steps(1, jump-both)"},
+		{Steps{1, 10}, "steps(1, StepPosition(10))"},
+	}
+	for i, line := range data {
+		if s := line.s.String(); s != line.expected {
+			t.Errorf("#%d: %q != %q", i, s, line.expected)
+		}
+	}
+}
+
+func ExampleSteps() {
+	s := Steps{N: 4, Pos: JumpStart}
+	for _, x := range []uint16{0, 16383, 16384, 32768, 65535} {
+		fmt.Printf("%5d: %d\n", x, s.Eval(x))
+	}
+	// Output:
+	//     0: 16384
+	// 16383: 16384
+	// 16384: 32768
+	// 32768: 49151
+	// 65535: 65535
+}
@@ -0,0 +1,84 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Keyframe is a stop in a Timeline.
+type Keyframe struct {
+	// T is the time of the keyframe, relative to the start of the Timeline.
+	T time.Duration
+	// V is the value at T.
+	V uint16
+	// Ease is the easing of the segment from this keyframe to the next one,
+	// e.g. a LUT, Steps or Curve.Precise().
+	//
+	// nil means linear. It is ignored on the last keyframe.
+	Ease Evaluator
+}
+
+// Timeline is a multi-stop animation, where each segment has its own easing.
+//
+// A hold is expressed with two consecutive keyframes with the same value. An
+// instantaneous jump is expressed with two consecutive keyframes at the same
+// time.
+type Timeline struct {
+	k []Keyframe
+}
+
+// NewTimeline returns a Timeline going through the keyframes in order.
+//
+// Keyframes must be sorted by time.
+func NewTimeline(k ...Keyframe) (*Timeline, error) {
+	if len(k) == 0 {
+		return nil, errors.New("at least one keyframe is required")
+	}
+	for i := 1; i < len(k); i++ {
+		if k[i].T < k[i-1].T {
+			return nil, fmt.Errorf("keyframe %d at %s is before keyframe %d at %s", i, k[i].T, i-1, k[i-1].T)
+		}
+	}
+	t := &Timeline{make([]Keyframe, len(k))}
+	copy(t.k, k)
+	for i := range t.k {
+		// A nil LUT in a non-nil Evaluator is linear too.
+		if l, ok := t.k[i].Ease.(LUT); ok && l == nil {
+			t.k[i].Ease = nil
+		}
+	}
+	return t, nil
+}
+
+// Duration returns the time of the last keyframe.
+func (t *Timeline) Duration() time.Duration {
+	return t.k[len(t.k)-1].T
+}
+
+// Eval returns the value at time at.
+//
+// Before the first keyframe, it returns the value of the first keyframe. After
+// the last keyframe, it returns the value of the last keyframe.
+func (t *Timeline) Eval(at time.Duration) uint16 {
+	// Find the last keyframe at or before at.
+	i := sort.Search(len(t.k), func(i int) bool { return t.k[i].T > at }) - 1
+	if i < 0 {
+		return t.k[0].V
+	}
+	if i == len(t.k)-1 {
+		return t.k[i].V
+	}
+	k0 := &t.k[i]
+	k1 := &t.k[i+1]
+	y := progress(at-k0.T, k1.T-k0.T)
+	if k0.Ease != nil {
+		y = k0.Ease.Eval(y)
+	}
+	return lerp16(k0.V, k1.V, y)
+}
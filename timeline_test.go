@@ -0,0 +1,115 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTimeline(t *testing.T) {
+	easeIn := Make(0.42, 0, 1, 1, 0)
+	easeOut := Curve{0, 0, 0.58, 1}.Precise()
+	tl, err := NewTimeline(
+		Keyframe{T: 0, V: 0, Ease: easeIn},
+		Keyframe{T: time.Second, V: 52428},
+		Keyframe{T: 2 * time.Second, V: 52428, Ease: easeOut},
+		Keyframe{T: 3 * time.Second, V: 6553},
+		Keyframe{T: 3 * time.Second, V: 65535},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := tl.Duration(); d != 3*time.Second {
+		t.Fatalf("unexpected duration %s", d)
+	}
+	data := []struct {
+		at       time.Duration
+		expected uint16
+	}{
+		{-time.Second, 0},
+		{0, 0},
+		{500 * time.Millisecond, lerp16(0, 52428, easeIn.Eval(32767))},
+		{time.Second, 52428},
+		{1500 * time.Millisecond, 52428},
+		{2 * time.Second, 52428},
+		{2250 * time.Millisecond, lerp16(52428, 6553, easeOut.Eval(16383))},
+		{2999 * time.Millisecond, lerp16(52428, 6553, easeOut.Eval(65469))},
+		{3 * time.Second, 65535},
+		{time.Hour, 65535},
+	}
+	for i, line := range data {
+		if v := tl.Eval(line.at); v != line.expected {
+			t.Errorf("#%d: Eval(%s) = %d; expected %d", i, line.at, v, line.expected)
+		}
+	}
+}
+
+func TestTimeline_Linear(t *testing.T) {
+	var l LUT
+	for _, ease := range []Evaluator{nil, l} {
+		tl, err := NewTimeline(Keyframe{T: time.Second, V: 100, Ease: ease}, Keyframe{T: 2 * time.Second, V: 200})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := tl.Eval(1500 * time.Millisecond); v != 149 {
+			t.Fatalf("unexpected value %d", v)
+		}
+	}
+}
+
+func TestTimeline_Err(t *testing.T) {
+	if _, err := NewTimeline(); err == nil {
+		t.Fatal("expected error")
+	}
+	_, err := NewTimeline(Keyframe{T: time.Second}, Keyframe{T: 0})
+	if err == nil || err.Error() != "keyframe 1 at 0s is before keyframe 0 at 1s" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func ExampleTimeline() {
+	// Fade in to 80% with ease-in, hold, then fade out to 10% with ease-out.
+	tl, err := NewTimeline(
+		Keyframe{T: 0, V: 0, Ease: Make(0.42, 0, 1, 1, 0)},
+		Keyframe{T: time.Second, V: 52428},
+		Keyframe{T: 2 * time.Second, V: 52428, Ease: Make(0, 0, 0.58, 1, 0)},
+		Keyframe{T: 3 * time.Second, V: 6553},
+	)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	for at := time.Duration(0); at <= tl.Duration(); at += 500 * time.Millisecond {
+		fmt.Printf("%5s: %d\n", at, tl.Eval(at))
+	}
+	// Output:
+	//    0s: 0
+	// 500ms: 16542
+	//    1s: 52428
+	//  1.5s: 52428
+	//    2s: 52428
+	//  2.5s: 21027
+	//    3s: 6553
+}
+
+func BenchmarkTimeline_Eval(b *testing.B) {
+	k := make([]Keyframe, 100)
+	l := Make(0.42, 0, 0.58, 1, 0)
+	for i := range k {
+		k[i] = Keyframe{T: time.Duration(i) * time.Second, V: uint16(i * 600), Ease: l}
+	}
+	tl, err := NewTimeline(k...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	r := uint16(0)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		r = tl.Eval(time.Duration(n%100) * 999 * time.Millisecond)
+	}
+	dummyI = r
+}
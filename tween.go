@@ -0,0 +1,111 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"image"
+	"image/color"
+)
+
+// Tween interpolates values of various types along an easing curve.
+//
+// For all methods, x is the progress in the uint16 domain; 0 returns `from`
+// and 65535 returns `to`. Integer types are interpolated with integer math
+// only.
+type Tween struct {
+	// Ease is the easing curve. nil means linear.
+	Ease Evaluator
+}
+
+// Int returns the value between from and to at x.
+func (t Tween) Int(from, to int, x uint16) int {
+	return int(lerp64(int64(from), int64(to), t.eval(x)))
+}
+
+// Float64 returns the value between from and to at x.
+func (t Tween) Float64(from, to float64, x uint16) float64 {
+	return from + (to-from)*float64(t.eval(x))*(1./65535.)
+}
+
+// RGBA64 returns the color between from and to at x.
+//
+// Each channel is interpolated independently.
+func (t Tween) RGBA64(from, to color.RGBA64, x uint16) color.RGBA64 {
+	y := t.eval(x)
+	return color.RGBA64{
+		R: lerp16(from.R, to.R, y),
+		G: lerp16(from.G, to.G, y),
+		B: lerp16(from.B, to.B, y),
+		A: lerp16(from.A, to.A, y),
+	}
+}
+
+// Point returns the point between from and to at x.
+func (t Tween) Point(from, to image.Point, x uint16) image.Point {
+	y := t.eval(x)
+	return image.Point{
+		X: int(lerp64(int64(from.X), int64(to.X), y)),
+		Y: int(lerp64(int64(from.Y), int64(to.Y), y)),
+	}
+}
+
+// Angle returns the angle between from and to at x.
+//
+// It takes the shortest path, wrapping around if necessary. When from and to
+// are exactly half a turn apart, it goes counterclockwise, toward smaller
+// values.
+func (t Tween) Angle(from, to Angle, x uint16) Angle {
+	// The delta in the range [-32768, 32767] is the shortest path.
+	d := int64(int16(to - from))
+	return from + Angle(lerp64(0, d, t.eval(x)))
+}
+
+func (t Tween) eval(x uint16) uint16 {
+	if t.Ease == nil {
+		return x
+	}
+	return t.Ease.Eval(x)
+}
+
+// Angle is an angle in binary angular measurement, where 65536 is a full
+// turn.
+//
+// It wraps around naturally on overflow.
+type Angle uint16
+
+// Degrees returns the Angle closest to d degrees.
+func Degrees(d float64) Angle {
+	t := d / 360.
+	t -= float64(int64(t))
+	if t < 0 {
+		t++
+	}
+	return Angle(uint32(t*65536.+0.5) & 0xFFFF)
+}
+
+// Degrees returns the angle in degrees in the range [0, 360).
+func (a Angle) Degrees() float64 {
+	return float64(a) * (360. / 65536.)
+}
+
+// lerp64 interpolates between from and to, where y is the position in the
+// uint16 domain.
+//
+// It doesn't overflow for any from and to.
+func lerp64(from, to int64, y uint16) int64 {
+	// The magnitude of the delta always fits in an uint64. The result is
+	// between from and to so the wrapping additions are exact.
+	if to >= from {
+		return int64(uint64(from) + scale64(uint64(to)-uint64(from), y))
+	}
+	return int64(uint64(from) - scale64(uint64(from)-uint64(to), y))
+}
+
+// scale64 returns d*y/65535 rounded down without overflowing.
+func scale64(d uint64, y uint16) uint64 {
+	q := d / 65535
+	r := d % 65535
+	return q*uint64(y) + r*uint64(y)/65535
+}
@@ -0,0 +1,168 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestTween_Int(t *testing.T) {
+	var tw Tween
+	data := []struct {
+		from, to int
+		x        uint16
+		expected int
+	}{
+		{0, 100, 0, 0},
+		{0, 100, 32767, 49},
+		{0, 100, 65535, 100},
+		{100, -100, 0, 100},
+		{100, -100, 32767, 1},
+		{100, -100, 65535, -100},
+		{math.MinInt32, math.MaxInt32, 65535, math.MaxInt32},
+		{math.MinInt32, math.MaxInt32, 32768, 32768},
+		{math.MaxInt32, math.MinInt32, 65535, math.MinInt32},
+		{math.MaxInt32, math.MinInt32, 32768, -32769},
+	}
+	for i, line := range data {
+		if v := tw.Int(line.from, line.to, line.x); v != line.expected {
+			t.Errorf("#%d: Int(%d, %d, %d) = %d; expected %d", i, line.from, line.to, line.x, v, line.expected)
+		}
+	}
+}
+
+func TestLerp64(t *testing.T) {
+	data := []struct {
+		from, to int64
+		y        uint16
+		expected int64
+	}{
+		{math.MinInt64, math.MaxInt64, 0, math.MinInt64},
+		{math.MinInt64, math.MaxInt64, 32768, 140739635871744},
+		{math.MinInt64, math.MaxInt64, 65535, math.MaxInt64},
+		{math.MaxInt64, math.MinInt64, 32768, -140739635871745},
+		{math.MaxInt64, math.MinInt64, 65535, math.MinInt64},
+		{-1 << 62, 1 << 62, 32768, 70369817935872},
+		{1 << 62, -1 << 62, 32767, 70369817935873},
+	}
+	for i, line := range data {
+		if v := lerp64(line.from, line.to, line.y); v != line.expected {
+			t.Errorf("#%d: lerp64(%d, %d, %d) = %d; expected %d", i, line.from, line.to, line.y, v, line.expected)
+		}
+	}
+}
+
+func TestTween_Ease(t *testing.T) {
+	l := Make(0.42, 0, 0.58, 1, 0)
+	tw := Tween{l}
+	for x := 0; x < 65536; x += 1000 {
+		if v := tw.Int(0, 65535, uint16(x)); v != int(l.Eval(uint16(x))) {
+			t.Fatalf("Int(0, 65535, %d) = %d; expected %d", x, v, l.Eval(uint16(x)))
+		}
+	}
+	if v := tw.Float64(-1, 1, 65535); v != 1 {
+		t.Fatalf("Float64 = %g", v)
+	}
+	if v := tw.Float64(-1, 1, 0); v != -1 {
+		t.Fatalf("Float64 = %g", v)
+	}
+	if v := tw.Float64(10, 20, 32768); math.Abs(v-(10+10*float64(l.Eval(32768))/65535.)) > 1e-9 {
+		t.Fatalf("Float64 = %g", v)
+	}
+}
+
+func TestTween_RGBA64(t *testing.T) {
+	var tw Tween
+	from := color.RGBA64{0, 65535, 1000, 65535}
+	to := color.RGBA64{65535, 0, 2000, 0}
+	if c := tw.RGBA64(from, to, 0); c != from {
+		t.Fatalf("%v", c)
+	}
+	if c := tw.RGBA64(from, to, 65535); c != to {
+		t.Fatalf("%v", c)
+	}
+	if c := tw.RGBA64(from, to, 32768); c != (color.RGBA64{32768, 32767, 1500, 32767}) {
+		t.Fatalf("%v", c)
+	}
+}
+
+func TestTween_Point(t *testing.T) {
+	var tw Tween
+	from := image.Point{-100, 100}
+	to := image.Point{100, 300}
+	if p := tw.Point(from, to, 0); p != from {
+		t.Fatalf("%v", p)
+	}
+	if p := tw.Point(from, to, 65535); p != to {
+		t.Fatalf("%v", p)
+	}
+	if p := tw.Point(from, to, 16384); p != (image.Point{-50, 150}) {
+		t.Fatalf("%v", p)
+	}
+}
+
+func TestTween_Angle(t *testing.T) {
+	var tw Tween
+	data := []struct {
+		from, to float64
+		x        uint16
+		expected float64
+	}{
+		{10, 20, 32768, 15},
+		{20, 10, 32768, 15},
+		// Wraps around.
+		{350, 10, 0, 350},
+		{350, 10, 32768, 0},
+		{350, 10, 65535, 10},
+		{10, 350, 32768, 0},
+		{10, 350, 16384, 5},
+		// Half a turn goes toward smaller values.
+		{90, 270, 32768, 0},
+	}
+	for i, line := range data {
+		v := tw.Angle(Degrees(line.from), Degrees(line.to), line.x).Degrees()
+		if math.Abs(v-line.expected) > 0.01 {
+			t.Errorf("#%d: Angle(%g, %g, %d) = %g; expected %g", i, line.from, line.to, line.x, v, line.expected)
+		}
+	}
+}
+
+func TestDegrees(t *testing.T) {
+	data := []struct {
+		d        float64
+		expected Angle
+	}{
+		{0, 0},
+		{90, 16384},
+		{-90, 49152},
+		{359.999, 0},
+		{360, 0},
+		{720 + 180, 32768},
+	}
+	for i, line := range data {
+		if a := Degrees(line.d); a != line.expected {
+			t.Errorf("#%d: Degrees(%g) = %d; expected %d", i, line.d, a, line.expected)
+		}
+	}
+}
+
+func ExampleTween() {
+	tw := Tween{Make(0.42, 0, 0.58, 1, 0)}
+	from := color.RGBA64{65535, 0, 0, 65535}
+	to := color.RGBA64{0, 0, 65535, 65535}
+	for _, x := range []uint16{0, 16384, 32768, 49152, 65535} {
+		fmt.Printf("%v\n", tw.RGBA64(from, to, x))
+	}
+	// Output:
+	// {65535 0 0 65535}
+	// {57042 0 8493 65535}
+	// {32766 0 32769 65535}
+	// {8491 0 57044 65535}
+	// {0 0 65535 65535}
+}
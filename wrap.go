@@ -0,0 +1,109 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"fmt"
+	"math"
+)
+
+// WrapMode defines how Wrap handles input outside of [0, 65535].
+type WrapMode int
+
+const (
+	// Clamp clamps the input to [0, 65535].
+	Clamp WrapMode = iota
+	// Repeat restarts the curve every 65536 units, like a uint16 overflowing.
+	Repeat
+	// Mirror plays the curve forward then backward (ping-pong), with a period
+	// of 131070 units.
+	Mirror
+	// Extrapolate extends the curve linearly using the slope of the first
+	// segment below 0 and the slope of the last segment above 65535.
+	Extrapolate
+)
+
+func (w WrapMode) String() string {
+	switch w {
+	case Clamp:
+		return "Clamp"
+	case Repeat:
+		return "Repeat"
+	case Mirror:
+		return "Mirror"
+	case Extrapolate:
+		return "Extrapolate"
+	default:
+		return fmt.Sprintf("WrapMode(%d)", int(w))
+	}
+}
+
+// Wrap evaluates a LUT over a domain wider than [0, 65535].
+type Wrap struct {
+	LUT  LUT
+	Mode WrapMode
+}
+
+// Eval returns the value at x.
+//
+// The result is always in [0, 65535] except with Extrapolate, where it is
+// saturated to the int32 range.
+func (w Wrap) Eval(x int32) int32 {
+	return w.eval(int64(x))
+}
+
+// EvalUint32 is the same as Eval for uint32 input.
+func (w Wrap) EvalUint32(x uint32) int32 {
+	return w.eval(int64(x))
+}
+
+func (w Wrap) eval(x int64) int32 {
+	switch w.Mode {
+	case Repeat:
+		if x %= 65536; x < 0 {
+			x += 65536
+		}
+	case Mirror:
+		if x %= 131070; x < 0 {
+			x += 131070
+		}
+		if x > 65535 {
+			x = 131070 - x
+		}
+	case Extrapolate:
+		if x < 0 || x > 65535 {
+			return w.extrapolate(x)
+		}
+	default:
+		if x < 0 {
+			x = 0
+		} else if x > 65535 {
+			x = 65535
+		}
+	}
+	return int32(w.LUT.Eval(uint16(x)))
+}
+
+func (w Wrap) extrapolate(x int64) int32 {
+	l := w.LUT
+	steps := int64(len(l) - 2)
+	var y int64
+	if x < 0 {
+		// Slope of the first segment; (0, l[0]) to (nextX, l[1]).
+		nextX := 65535 / steps
+		y = int64(l[0]) + (int64(l[1])-int64(l[0]))*x/nextX
+	} else {
+		// Slope of the last segment; (baseX, l[steps-1]) to (65535, l[steps]).
+		baseX := (steps - 1) * 65535 / steps
+		y = int64(l[steps]) + (int64(l[steps])-int64(l[steps-1]))*(x-65535)/(65535-baseX)
+	}
+	if y < math.MinInt32 {
+		return math.MinInt32
+	}
+	if y > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(y)
+}
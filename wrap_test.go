@@ -0,0 +1,132 @@
+// Copyright 2016 Marc-Antoine Ruel. All rights reserved.
+// Use of this source code is governed under the Apache License, Version 2.0
+// that can be found in the LICENSE file.
+
+package fastbezier
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	l := Make(0.42, 0, 0.58, 1, 0)
+	e := func(x uint16) int32 {
+		return int32(l.Eval(x))
+	}
+	data := []struct {
+		mode     WrapMode
+		x        int32
+		expected int32
+	}{
+		{Clamp, math.MinInt32, 0},
+		{Clamp, -1, 0},
+		{Clamp, 0, 0},
+		{Clamp, 1, e(1)},
+		{Clamp, 65534, e(65534)},
+		{Clamp, 65535, 65535},
+		{Clamp, 65536, 65535},
+		{Clamp, math.MaxInt32, 65535},
+
+		{Repeat, -65537, e(65535)},
+		{Repeat, -65536, 0},
+		{Repeat, -1, 65535},
+		{Repeat, 0, 0},
+		{Repeat, 1, e(1)},
+		{Repeat, 65535, 65535},
+		{Repeat, 65536, 0},
+		{Repeat, 65537, e(1)},
+		{Repeat, 3*65536 + 1000, e(1000)},
+
+		{Mirror, -131070, 0},
+		{Mirror, -65535, 65535},
+		{Mirror, -1, e(1)},
+		{Mirror, 0, 0},
+		{Mirror, 1, e(1)},
+		{Mirror, 65534, e(65534)},
+		{Mirror, 65535, 65535},
+		{Mirror, 65536, e(65534)},
+		{Mirror, 131069, e(1)},
+		{Mirror, 131070, 0},
+		{Mirror, 131071, e(1)},
+
+		{Extrapolate, 0, 0},
+		{Extrapolate, 65535, 65535},
+		{Extrapolate, 32767, e(32767)},
+	}
+	for i, line := range data {
+		w := Wrap{l, line.mode}
+		if v := w.Eval(line.x); v != line.expected {
+			t.Errorf("#%d: %s.Eval(%d) = %d; expected %d", i, line.mode, line.x, v, line.expected)
+		}
+	}
+}
+
+func TestWrap_Extrapolate(t *testing.T) {
+	// A straight line must continue straight.
+	w := Wrap{LUT{0, 32767, 65535, 65535}, Extrapolate}
+	data := []struct {
+		x, expected int32
+	}{
+		{-65535, -65535},
+		{-1, -1},
+		{65536, 65536},
+		{131070, 131070},
+		{math.MaxInt32, math.MaxInt32},
+		{math.MinInt32, math.MinInt32},
+	}
+	for i, line := range data {
+		if v := w.Eval(line.x); v != line.expected {
+			t.Errorf("#%d: Eval(%d) = %d; expected %d", i, line.x, v, line.expected)
+		}
+	}
+
+	// The ease-in-out curve is flat at both ends, with some rounding.
+	w.LUT = Make(0.42, 0, 0.58, 1, 0)
+	for _, x := range []int32{-1, -1000, -65535} {
+		if v := w.Eval(x); v > 0 || v < x/10 {
+			t.Errorf("Eval(%d) = %d", x, v)
+		}
+	}
+	for _, x := range []int32{65536, 70000, 131070} {
+		if v := w.Eval(x); v < 65535 || v > 65535+(x-65535)/10 {
+			t.Errorf("Eval(%d) = %d", x, v)
+		}
+	}
+
+	// Saturation.
+	w.LUT = LUT{0, 0, 65535, 65535}
+	if v := w.EvalUint32(math.MaxUint32); v != math.MaxInt32 {
+		t.Errorf("EvalUint32(MaxUint32) = %d", v)
+	}
+}
+
+func TestWrap_EvalUint32(t *testing.T) {
+	l := Make(0.42, 0, 0.58, 1, 0)
+	data := []struct {
+		mode     WrapMode
+		x        uint32
+		expected int32
+	}{
+		{Clamp, math.MaxUint32, 65535},
+		{Repeat, math.MaxUint32, 65535},
+		{Repeat, 1 << 16, 0},
+		{Mirror, 1 << 16, int32(l.Eval(65534))},
+		{Mirror, math.MaxUint32, int32(l.Eval(uint16(131070 - math.MaxUint32%131070)))},
+	}
+	for i, line := range data {
+		w := Wrap{l, line.mode}
+		if v := w.EvalUint32(line.x); v != line.expected {
+			t.Errorf("#%d: %s.EvalUint32(%d) = %d; expected %d", i, line.mode, line.x, v, line.expected)
+		}
+	}
+}
+
+func TestWrapMode_String(t *testing.T) {
+	if s := Mirror.String(); s != "Mirror" {
+		t.Fatal(s)
+	}
+	if s := WrapMode(10).String(); s != "WrapMode(10)" {
+		t.Fatal(s)
+	}
+}